@@ -0,0 +1,38 @@
+package validate
+
+import (
+	"regexp"
+	"strconv"
+)
+
+var cardExpiryRx = regexp.MustCompile(`^(\d{2})/(\d{2}|\d{4})$`)
+
+// IsCardExpiry Checks that a card expiry in the form MM/YY or MM/YYYY is
+// valid and has not passed.  A card remains valid until the end of its expiry
+// month, as reported by Now.
+func IsCardExpiry(
+	field string,
+	errors Errors,
+	v string,
+) {
+	m := cardExpiryRx.FindStringSubmatch(v)
+	if m == nil {
+		addError("card_expiry", field, errors, "Must be a valid expiry date (MM/YY)")
+		return
+	}
+
+	month, _ := strconv.Atoi(m[1])
+	year, _ := strconv.Atoi(m[2])
+	if month < 1 || month > 12 {
+		addError("card_expiry", field, errors, "Must be a valid expiry date (MM/YY)")
+		return
+	}
+	if len(m[2]) == 2 {
+		year += 2000
+	}
+
+	now := Now()
+	if year < now.Year() || (year == now.Year() && month < int(now.Month())) {
+		addError("card_expiry", field, errors, "Card has expired")
+	}
+}
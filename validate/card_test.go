@@ -0,0 +1,43 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIsCardExpiry(t *testing.T) {
+	Now = func() time.Time { return time.Date(2024, time.March, 31, 23, 59, 0, 0, time.UTC) }
+	defer func() { Now = time.Now }()
+
+	invalid := []string{"Must be a valid expiry date (MM/YY)"}
+	expired := []string{"Card has expired"}
+
+	tests := []struct {
+		v    string
+		want []string
+	}{
+		{"03/24", nil},
+		{"03/2024", nil},
+		{"04/24", nil},
+		{"01/25", nil},
+		{"12/2030", nil},
+		{"02/24", expired},
+		{"02/2024", expired},
+		{"12/23", expired},
+		{"00/25", invalid},
+		{"13/25", invalid},
+		{"3/24", invalid},
+		{"03/924", invalid},
+		{"03-24", invalid},
+		{"", invalid},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsCardExpiry("expiry", errs, tt.v)
+		if got := errs["expiry"]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("IsCardExpiry(%q) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+}
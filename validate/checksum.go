@@ -0,0 +1,135 @@
+package validate
+
+import "strings"
+
+var digitSeparators = strings.NewReplacer(" ", "", "-", "")
+
+// Digits converts a string of ASCII digits, such as "0123", to their values,
+// such as [0 1 2 3].  Like Luhn, it requires cleaned input: false is returned
+// for an empty string or any character other than 0-9.
+func Digits(s string) ([]int, bool) {
+	if !isDigits(s) {
+		return nil, false
+	}
+
+	d := make([]int, len(s))
+	for i := 0; i < len(s); i++ {
+		d[i] = int(s[i] - '0')
+	}
+	return d, true
+}
+
+// WeightedMod returns the sum of each digit multiplied by its weight, modulo
+// mod, which is the core of most check-digit schemes.  Weights are applied
+// from the left and repeat when there are fewer weights than digits, so
+// weights [1 3] alternate as in GTIN.  The result is never negative.  Like
+// Digits, it returns false rather than a result when given unusable input:
+// here, no weights or a mod that isn't positive.
+func WeightedMod(digits []int, weights []int, mod int) (int, bool) {
+	if len(weights) == 0 || mod <= 0 {
+		return 0, false
+	}
+
+	sum := 0
+	for i, d := range digits {
+		sum += d * weights[i%len(weights)]
+	}
+
+	r := sum % mod
+	if r < 0 {
+		r += mod
+	}
+	return r, true
+}
+
+// Mod97 returns the remainder of the decimal number written in digits divided
+// by 97, as used by IBAN check digits.  The number may be far longer than an
+// int can hold.  Like Digits, it returns false unless digits is cleaned.
+func Mod97(digits string) (int, bool) {
+	if !isDigits(digits) {
+		return 0, false
+	}
+
+	r := 0
+	for i := 0; i < len(digits); i++ {
+		r = (r*10 + int(digits[i]-'0')) % 97
+	}
+	return r, true
+}
+
+// Luhn reports whether digits passes the Luhn (mod 10) checksum, as used by
+// card numbers and IMEIs.  digits must already be cleaned: any character other
+// than 0-9, including spaces and dashes, makes it return false, as does an
+// empty string.
+func Luhn(digits string) bool {
+	d, ok := Digits(digits)
+	if !ok {
+		return false
+	}
+
+	// Every second digit from the right is doubled, and the digits of the
+	// product summed, which for a single digit product over 9 is d*2-9.
+	sum := 0
+	for i := range d {
+		v := d[len(d)-1-i]
+		if i%2 == 1 {
+			v *= 2
+			if v > 9 {
+				v -= 9
+			}
+		}
+		sum += v
+	}
+
+	return sum%10 == 0
+}
+
+// IsLuhn Checks that the value passes the Luhn checksum.  Spaces and dashes
+// are stripped first, so "4111 1111 1111 1111" is accepted.
+func IsLuhn(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if !Luhn(digitSeparators.Replace(v)) {
+		addError("luhn", field, errors, "Must have a valid check digit")
+	}
+}
+
+// IsIMEI Checks that the value is a 15-digit IMEI with a valid Luhn check
+// digit.
+func IsIMEI(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if len(v) != 15 || !Luhn(v) {
+		addError("imei", field, errors, "Must be a valid IMEI")
+	}
+}
+
+// IsIMEISV Checks that the value is a 16-digit IMEISV.  Unlike an IMEI, the
+// software version variant carries no check digit, so only the length and
+// digits are checked.
+func IsIMEISV(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if len(v) != 16 || !isDigits(v) {
+		addError("imeisv", field, errors, "Must be a valid IMEISV")
+	}
+}
+
+// isDigits reports whether s is non-empty and made up only of ASCII digits.
+func isDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDigits(t *testing.T) {
+	if d, ok := Digits("0123"); !ok || !reflect.DeepEqual(d, []int{0, 1, 2, 3}) {
+		t.Errorf(`Digits("0123") = %v, %v`, d, ok)
+	}
+	for _, v := range []string{"", "12 3", "12-3", "١٢"} {
+		if d, ok := Digits(v); ok {
+			t.Errorf("Digits(%q) = %v, true, want false", v, d)
+		}
+	}
+}
+
+func TestWeightedMod(t *testing.T) {
+	// GTIN-13 4006381333931: weights 1,3 over the first 12 digits give a
+	// remainder whose complement to 10 is the check digit 1.
+	d, _ := Digits("400638133393")
+	if r, ok := WeightedMod(d, []int{1, 3}, 10); !ok || (10-r)%10 != 1 {
+		t.Errorf("GTIN WeightedMod = %d, %v", r, ok)
+	}
+
+	if r, ok := WeightedMod([]int{1, 2, 3}, []int{3, 2, 1}, 11); !ok || r != 10 {
+		t.Errorf("WeightedMod mod 11 = %d, %v, want 10", r, ok)
+	}
+	if r, ok := WeightedMod([]int{5}, []int{-1}, 7); !ok || r != 2 {
+		t.Errorf("WeightedMod negative sum = %d, %v, want 2", r, ok)
+	}
+	if _, ok := WeightedMod([]int{1}, nil, 10); ok {
+		t.Error("WeightedMod with no weights = true, want false")
+	}
+	if _, ok := WeightedMod([]int{1}, []int{1}, 0); ok {
+		t.Error("WeightedMod with mod 0 = true, want false")
+	}
+}
+
+func TestMod97(t *testing.T) {
+	// GB82WEST12345698765432 rearranged and converted to digits, as for an
+	// IBAN check, leaves a remainder of 1.
+	if r, ok := Mod97("3214282912345698765432161182"); !ok || r != 1 {
+		t.Errorf("Mod97 = %d, %v, want 1", r, ok)
+	}
+	if _, ok := Mod97("12AB"); ok {
+		t.Error(`Mod97("12AB") = true, want false`)
+	}
+}
+
+func TestLuhn(t *testing.T) {
+	for v, want := range map[string]bool{
+		"79927398713":      true,
+		"4111111111111111": true,
+		"4111111111111112": false,
+		"4111 1111":        false,
+		"":                 false,
+	} {
+		if got := Luhn(v); got != want {
+			t.Errorf("Luhn(%q) = %v, want %v", v, got, want)
+		}
+	}
+
+	errs := Errors{}
+	IsLuhn("card", errs, "4111-1111 1111-1111")
+	if len(errs) != 0 {
+		t.Errorf("IsLuhn with separators = %v, want no errors", errs)
+	}
+}
+
+func TestIsIMEI(t *testing.T) {
+	tests := []struct {
+		v     string
+		valid bool
+	}{
+		{"490154203237518", true},
+		{"490154203237519", false}, // bad check digit
+		{"49015420323751", false},
+		{"4901542032375180", false},
+		{"49015420323751a", false},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsIMEI("imei", errs, tt.v)
+		if got := len(errs) == 0; got != tt.valid {
+			t.Errorf("IsIMEI(%q) valid = %v, want %v", tt.v, got, tt.valid)
+		}
+	}
+}
+
+func TestIsIMEISV(t *testing.T) {
+	for v, valid := range map[string]bool{
+		"4901542032375181": true,
+		"490154203237518":  false,
+		"49015420323751a1": false,
+	} {
+		errs := Errors{}
+		IsIMEISV("imeisv", errs, v)
+		if got := len(errs) == 0; got != valid {
+			t.Errorf("IsIMEISV(%q) valid = %v, want %v", v, got, valid)
+		}
+	}
+}
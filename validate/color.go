@@ -0,0 +1,81 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// HasSufficientContrast Checks that the foreground and background hex colors,
+// written as "#RGB" or "#RRGGBB", have at least minRatio WCAG contrast, such
+// as 4.5 for normal text or 3 for large text.
+func HasSufficientContrast(
+	field string,
+	errors Errors,
+	fg string,
+	bg string,
+	minRatio float64,
+) {
+	fr, fgOk := parseHexColor(fg)
+	br, bgOk := parseHexColor(bg)
+	if !fgOk || !bgOk {
+		addError("contrast", field, errors, "Must be a valid hex color")
+		return
+	}
+
+	ratio := ContrastRatio(fr, br)
+	if ratio < minRatio {
+		// Round down, so a ratio just short of 4.5 isn't shown as 4.5.
+		shown := math.Floor(ratio*10) / 10
+		addError("contrast", field, errors, fmt.Sprintf("Insufficient color contrast (%.1f:1, need %.1f:1)", shown, minRatio))
+	}
+}
+
+// ContrastRatio returns the WCAG 2 contrast ratio between two sRGB colors,
+// from 1 for identical colors up to 21 for black on white.
+func ContrastRatio(a, b [3]uint8) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// relativeLuminance returns the WCAG relative luminance of an sRGB color,
+// linearizing each channel before weighting it.
+func relativeLuminance(c [3]uint8) float64 {
+	var lin [3]float64
+	for i, v := range c {
+		s := float64(v) / 255
+		if s <= 0.03928 {
+			lin[i] = s / 12.92
+		} else {
+			lin[i] = math.Pow((s+0.055)/1.055, 2.4)
+		}
+	}
+	return 0.2126*lin[0] + 0.7152*lin[1] + 0.0722*lin[2]
+}
+
+// parseHexColor parses "#RGB" or "#RRGGBB" into its red, green and blue
+// channels.
+func parseHexColor(s string) ([3]uint8, bool) {
+	var c [3]uint8
+	if len(s) == 0 || s[0] != '#' || !isHex(s[1:]) {
+		return c, false
+	}
+
+	hex := s[1:]
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	case 6:
+	default:
+		return c, false
+	}
+
+	for i := range c {
+		v, _ := strconv.ParseUint(hex[i*2:i*2+2], 16, 8)
+		c[i] = uint8(v)
+	}
+	return c, true
+}
@@ -0,0 +1,172 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MatchesAny Checks that the value is exactly equal to at least one of the
+// candidates, such as one of several currently valid tokens.
+func MatchesAny[T comparable](
+	field string,
+	errors Errors,
+	v T,
+	candidates []T,
+	message string,
+) {
+	matchesAny("matches_any", field, errors, v, candidates, message)
+}
+
+// matchesAny is MatchesAny, recording any error under the calling validator's
+// rule.
+func matchesAny[T comparable](
+	rule string,
+	field string,
+	errors Errors,
+	v T,
+	candidates []T,
+	message string,
+) {
+	for _, c := range candidates {
+		if v == c {
+			return
+		}
+	}
+
+	addError(rule, field, errors, message)
+}
+
+// IsOneOfFunc Checks the value against a caller-supplied predicate, recording
+// the message if it returns false.  It is the escape hatch for membership
+// rules that can't be expressed as equality, such as allowed state
+// transitions.
+func IsOneOfFunc[T any](
+	field string,
+	errors Errors,
+	v T,
+	allowed func(T) bool,
+	message string,
+) {
+	if !allowed(v) {
+		addError("one_of_func", field, errors, message)
+	}
+}
+
+// IsOneOfWithSuggestion Checks that the value is one of the allowed strings.
+// On failure it suggests the closest allowed value by Levenshtein distance,
+// ignoring case, as in `Unknown value "actve", did you mean "active"?`,
+// provided one is within a third of the value's length (and at least 1) edits.
+// Otherwise the allowed values are listed.
+func IsOneOfWithSuggestion(
+	field string,
+	errors Errors,
+	v string,
+	allowed []string,
+) {
+	lower := strings.ToLower(v)
+	best, bestDist := "", -1
+	for _, a := range allowed {
+		if a == v {
+			return
+		}
+		if d := Levenshtein(lower, strings.ToLower(a)); bestDist < 0 || d < bestDist {
+			best, bestDist = a, d
+		}
+	}
+
+	limit := utf8.RuneCountInString(v) / 3
+	if limit < 1 {
+		limit = 1
+	}
+
+	if bestDist >= 0 && bestDist <= limit {
+		addError("one_of", field, errors, fmt.Sprintf("Unknown value %q, did you mean %q?", v, best))
+		return
+	}
+
+	addError("one_of", field, errors, fmt.Sprintf("Must be one of: %s", strings.Join(allowed, ", ")))
+}
+
+// Levenshtein returns the edit distance between a and b: the fewest
+// single-rune insertions, deletions or substitutions turning one into the
+// other.
+func Levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
+
+func minInt(first int, rest ...int) int {
+	m := first
+	for _, v := range rest {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
+
+// IsEqualFold Checks that v equals other ignoring case, under Unicode case
+// folding, recording the message otherwise.  It suits confirming values like
+// usernames, where "Admin" and "admin" are the same.  It must NOT be used to
+// confirm passwords, where case matters.
+func IsEqualFold(
+	field string,
+	errors Errors,
+	v string,
+	other string,
+	message string,
+) {
+	if !strings.EqualFold(v, other) {
+		addError("equal_fold", field, errors, message)
+	}
+}
+
+// IsDistinctFrom Checks that v differs from the current value, recording the
+// message when they are equal, such as a new password matching the old one.
+// The caller supplies whatever representation is appropriate to compare: for
+// a password that is a hash, never the stored plaintext.
+func IsDistinctFrom[T comparable](
+	field string,
+	errors Errors,
+	v T,
+	current T,
+	message string,
+) {
+	if v == current {
+		addError("distinct_from", field, errors, message)
+	}
+}
+
+// IsDistinctFromFold is like IsDistinctFrom for strings, but treats values
+// differing only in case as the same, as suits usernames and emails.
+func IsDistinctFromFold(
+	field string,
+	errors Errors,
+	v string,
+	current string,
+	message string,
+) {
+	if strings.EqualFold(v, current) {
+		addError("distinct_from_fold", field, errors, message)
+	}
+}
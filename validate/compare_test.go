@@ -0,0 +1,17 @@
+package validate
+
+import "testing"
+
+func TestIsEqualFold(t *testing.T) {
+	errs := Errors{}
+	IsEqualFold("same", errs, "Admin", "admin", "Usernames must match")
+	IsEqualFold("unicode", errs, "STRASSE", "strasse", "Usernames must match")
+	IsEqualFold("different", errs, "Admin", "admins", "Usernames must match")
+
+	if len(errs["same"]) != 0 || len(errs["unicode"]) != 0 {
+		t.Errorf("case-only differences were rejected: %v", errs)
+	}
+	if got := errs["different"]; len(got) != 1 || got[0] != "Usernames must match" {
+		t.Errorf(`errors["different"] = %v, want the caller's message`, got)
+	}
+}
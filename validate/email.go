@@ -0,0 +1,76 @@
+package validate
+
+import "strings"
+
+// NormalizeEmail trims surrounding whitespace and lowercases the domain of an
+// email address, leaving the local part as given.  It is intended to be run
+// after IsEmail and before storing or comparing for uniqueness.  Provider
+// specific rewriting, such as dropping dots or "+tag" suffixes for Gmail, is
+// intentionally not done, since it would silently merge addresses that other
+// providers treat as distinct.
+func NormalizeEmail(v string) string {
+	v = strings.TrimSpace(v)
+	at := strings.LastIndex(v, "@")
+	if at < 0 {
+		return v
+	}
+	return v[:at+1] + strings.ToLower(v[at+1:])
+}
+
+// NormalizeEmailLower is like NormalizeEmail, but lowercases the whole
+// address.  Local parts are case-sensitive by the standard, but almost every
+// provider treats them otherwise, so this is usually the better key for
+// detecting duplicate accounts.
+func NormalizeEmailLower(v string) string {
+	return strings.ToLower(strings.TrimSpace(v))
+}
+
+// IsAllowedEmailDomain Checks that the value is a valid email address whose
+// domain is not in blockedDomains, compared case-insensitively.  Only an exact
+// domain match is blocked, so blocking "mailinator.com" still allows
+// "foo.mailinator.com"; use IsAllowedEmailDomainSuffix to block subdomains too.
+func IsAllowedEmailDomain(
+	field string,
+	errors Errors,
+	v string,
+	blockedDomains []string,
+) {
+	isAllowedEmailDomain("allowed_email_domain", field, errors, v, blockedDomains, false)
+}
+
+// IsAllowedEmailDomainSuffix is like IsAllowedEmailDomain, but also blocks any
+// subdomain of a blocked domain, so blocking "mailinator.com" rejects
+// "foo.mailinator.com" as well.
+func IsAllowedEmailDomainSuffix(
+	field string,
+	errors Errors,
+	v string,
+	blockedDomains []string,
+) {
+	isAllowedEmailDomain("allowed_email_domain", field, errors, v, blockedDomains, true)
+}
+
+func isAllowedEmailDomain(
+	rule string,
+	field string,
+	errors Errors,
+	v string,
+	blockedDomains []string,
+	suffix bool,
+) {
+	if !EmailRx.MatchString(v) {
+		addError(rule, field, errors, "Email address is invalid")
+		return
+	}
+
+	// A trailing dot names the same domain in fully qualified form, so it is
+	// dropped from both sides or "mailinator.com." would slip past.
+	domain := strings.TrimSuffix(strings.ToLower(v[strings.LastIndex(v, "@")+1:]), ".")
+	for _, b := range blockedDomains {
+		b = strings.TrimSuffix(strings.ToLower(b), ".")
+		if domain == b || (suffix && strings.HasSuffix(domain, "."+b)) {
+			addError(rule, field, errors, "Email provider not allowed")
+			return
+		}
+	}
+}
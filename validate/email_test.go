@@ -0,0 +1,40 @@
+package validate
+
+import "testing"
+
+func TestIsAllowedEmailDomain(t *testing.T) {
+	blocked := []string{"Mailinator.com"}
+
+	tests := []struct {
+		v           string
+		exactValid  bool
+		suffixValid bool
+	}{
+		{"x@example.com", true, true},
+		{"x@mailinator.com", false, false},
+		{"x@MAILINATOR.COM", false, false},
+		{"x@mailinator.com.", false, false},
+		{"x@foo.mailinator.com", true, false},
+		{"x@foo.mailinator.com.", true, false},
+		{"x@notmailinator.com", true, true},
+	}
+
+	for _, tt := range tests {
+		exact, suffix := Errors{}, Errors{}
+		IsAllowedEmailDomain("email", exact, tt.v, blocked)
+		IsAllowedEmailDomainSuffix("email", suffix, tt.v, blocked)
+
+		if got := len(exact) == 0; got != tt.exactValid {
+			t.Errorf("IsAllowedEmailDomain(%q) valid = %v, want %v", tt.v, got, tt.exactValid)
+		}
+		if got := len(suffix) == 0; got != tt.suffixValid {
+			t.Errorf("IsAllowedEmailDomainSuffix(%q) valid = %v, want %v", tt.v, got, tt.suffixValid)
+		}
+	}
+
+	errs := Errors{}
+	IsAllowedEmailDomain("email", errs, "not-an-email", blocked)
+	if got := errs["email"]; len(got) != 1 || got[0] != "Email address is invalid" {
+		t.Errorf("invalid address = %v, want only the invalid email error", got)
+	}
+}
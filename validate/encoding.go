@@ -0,0 +1,84 @@
+package validate
+
+import (
+	"encoding/base32"
+	"fmt"
+)
+
+// IsHexBytesLength Checks that the value is hex encoded and decodes to exactly
+// byteLen bytes, such as a 32-byte secret written as 64 hex characters.
+func IsHexBytesLength(
+	field string,
+	errors Errors,
+	v string,
+	byteLen int,
+) {
+	if len(v) != byteLen*2 || !isHex(v) {
+		addError("hex_bytes_length", field, errors, fmt.Sprintf("Must be a %d-byte hex value", byteLen))
+	}
+}
+
+// isHex reports whether s is non-empty and made up only of hex digits, in
+// either case.
+func isHex(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// MinTOTPSecretBytes is the shortest decoded secret IsTOTPSecret accepts, the
+// 128 bits RFC 4226 requires of an HOTP/TOTP key.
+const MinTOTPSecretBytes = 16
+
+// IsBase32 Checks that the value is padded standard base32, as produced by
+// base32.StdEncoding.  Use IsBase32Unpadded where the trailing "=" padding is
+// omitted.
+func IsBase32(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if _, err := base32.StdEncoding.DecodeString(v); err != nil || len(v) == 0 {
+		addError("base32", field, errors, "Must be valid base32")
+	}
+}
+
+// IsBase32Unpadded Checks that the value is standard base32 without padding.
+func IsBase32Unpadded(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(v); err != nil || len(v) == 0 {
+		addError("base32", field, errors, "Must be valid base32")
+	}
+}
+
+// IsTOTPSecret Checks that the value is a base32 secret, padded or not, that
+// decodes to at least MinTOTPSecretBytes bytes, as entered into an
+// authenticator app.
+func IsTOTPSecret(
+	field string,
+	errors Errors,
+	v string,
+) {
+	b, err := base32.StdEncoding.DecodeString(v)
+	if err != nil {
+		b, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(v)
+	}
+	if err != nil || len(v) == 0 {
+		addError("totp_secret", field, errors, "Must be valid base32")
+		return
+	}
+
+	if len(b) < MinTOTPSecretBytes {
+		addError("totp_secret", field, errors, "Secret is too short")
+	}
+}
@@ -0,0 +1,33 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsTOTPSecret(t *testing.T) {
+	tests := []struct {
+		name string
+		v    string
+		want []string
+	}{
+		{"padded", "GAYTEMZUGU3DOOBZMFRGGZDFMY======", nil},
+		{"unpadded", "GAYTEMZUGU3DOOBZMFRGGZDFMY", nil},
+		{"full block", "GAYTEMZUGU3DOOBZMFRGGZDFMZTWQ2LK", nil},
+		{"full block with padding", "GAYTEMZUGU3DOOBZMFRGGZDFMZTWQ2LK=====", []string{"Must be valid base32"}},
+		{"short padding", "GAYTEMZUGU3DOOBZMFRGGZDFMY==", []string{"Must be valid base32"}},
+		{"lowercase", "gaytemzugu3doobzmfrggzdfmy", []string{"Must be valid base32"}},
+		{"empty", "", []string{"Must be valid base32"}},
+		{"too short", "GAYTEMZUGU3DOOBZMFRGGZDF", []string{"Secret is too short"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Errors{}
+			IsTOTPSecret("secret", errs, tt.v)
+			if got := errs["secret"]; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("IsTOTPSecret(%q) = %v, want %v", tt.v, got, tt.want)
+			}
+		})
+	}
+}
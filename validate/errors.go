@@ -0,0 +1,171 @@
+package validate
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// FoldInto records every message from sub against the single field on the
+// receiver, discarding sub's own field keys.  Messages are added in field
+// order so the result is stable.  They were already formatted when first
+// added, so ErrorFormatter is not applied again.
+func (e Errors) FoldInto(field string, sub Errors) {
+	keys := make([]string, 0, len(sub))
+	for k := range sub {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		e[field] = append(e[field], sub[k]...)
+	}
+}
+
+// Wrap returns a new Errors holding all of the receiver's messages under the
+// single field.  It is useful when a composite value has only one input on
+// the frontend.
+func (e Errors) Wrap(field string) Errors {
+	wrapped := Errors{}
+	wrapped.FoldInto(field, e)
+	return wrapped
+}
+
+// Clone returns a deep copy of the receiver.  Each field's messages are copied
+// into a new slice, so errors added to the clone never reach the original
+// through a shared backing array.
+func (e Errors) Clone() Errors {
+	c := make(Errors, len(e))
+	for k, msgs := range e {
+		c[k] = append([]string(nil), msgs...)
+	}
+	return c
+}
+
+// FormErrors holds both field errors and general errors that apply to the
+// submission as a whole, such as "These credentials are invalid".
+type FormErrors struct {
+	Errors  Errors
+	General []string
+}
+
+// NewFormErrors returns a FormErrors holding the given field errors, which
+// may be nil.
+func NewFormErrors(errors Errors) *FormErrors {
+	if errors == nil {
+		errors = Errors{}
+	}
+	return &FormErrors{Errors: errors}
+}
+
+// AddGeneral records a message that isn't tied to any one field.
+func (f *FormErrors) AddGeneral(msg string) {
+	f.General = append(f.General, msg)
+}
+
+// HasErrors reports whether any field or general error has been recorded.
+func (f *FormErrors) HasErrors() bool {
+	return len(f.Errors) > 0 || len(f.General) > 0
+}
+
+// ToErrors returns the field errors as plain Errors, with the general
+// messages recorded against generalField.  The result is an independent copy.
+func (f *FormErrors) ToErrors(generalField string) Errors {
+	e := f.Errors.Clone()
+	if len(f.General) > 0 {
+		e[generalField] = append(e[generalField], f.General...)
+	}
+	return e
+}
+
+// MarshalJSON encodes the errors as {"errors": {...}, "general": [...]}.
+// Both sections are always present, as {} and [] when empty.
+func (f FormErrors) MarshalJSON() ([]byte, error) {
+	fields := f.Errors
+	if fields == nil {
+		fields = Errors{}
+	}
+	general := f.General
+	if general == nil {
+		general = []string{}
+	}
+
+	return json.Marshal(struct {
+		Errors  Errors   `json:"errors"`
+		General []string `json:"general"`
+	}{fields, general})
+}
+
+// Result separates blocking errors from advisory warnings, such as "This
+// password is valid but weak".  The IsX validators write to Errors, while
+// AddWarning and the WarnX helpers write to Warnings:
+//
+//	r := NewResult()
+//	IsStringLength("password", r.Errors, pw, 8, 128)
+//	WarnStringMinLength("password", r.Warnings, pw, 12)
+//
+// Warnings are never reported to OnError, which counts only failures.
+type Result struct {
+	Errors   Errors
+	Warnings Errors
+}
+
+// NewResult returns a Result with empty errors and warnings.
+func NewResult() *Result {
+	return &Result{Errors: Errors{}, Warnings: Errors{}}
+}
+
+// AddWarning records an advisory message against the field.
+func (r *Result) AddWarning(field string, msg string) {
+	AddError(field, r.Warnings, msg)
+}
+
+// HasErrors reports whether any blocking error has been recorded.
+func (r *Result) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// HasWarnings reports whether any warning has been recorded.
+func (r *Result) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
+// MarshalJSON encodes the result as {"errors": {...}, "warnings": {...}}.
+// Both sections are always present, as {} when empty.
+func (r Result) MarshalJSON() ([]byte, error) {
+	errs, warnings := r.Errors, r.Warnings
+	if errs == nil {
+		errs = Errors{}
+	}
+	if warnings == nil {
+		warnings = Errors{}
+	}
+
+	return json.Marshal(struct {
+		Errors   Errors `json:"errors"`
+		Warnings Errors `json:"warnings"`
+	}{errs, warnings})
+}
+
+// Filter returns a new Errors holding only the fields for which predicate
+// returns true.  Like Clone, the messages are copied, so the result shares
+// nothing with the receiver.
+func (e Errors) Filter(predicate func(field string) bool) Errors {
+	f := Errors{}
+	for k, msgs := range e {
+		if predicate(k) {
+			f[k] = append([]string(nil), msgs...)
+		}
+	}
+	return f
+}
+
+// WithPrefix returns a new Errors holding only the fields whose key starts
+// with prefix, such as "address." for every member of an address section.
+// The prefix is matched as given, so "address" would also match
+// "addressLine2".
+func (e Errors) WithPrefix(prefix string) Errors {
+	return e.Filter(func(field string) bool {
+		return strings.HasPrefix(field, prefix)
+	})
+}
@@ -0,0 +1,58 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloneIsIndependent(t *testing.T) {
+	// Give the slice spare capacity, so an append that wrongly shared the
+	// backing array would write into the original's memory.
+	msgs := make([]string, 1, 4)
+	msgs[0] = "first"
+	original := Errors{"name": msgs}
+
+	clone := original.Clone()
+	clone.Add("name", "second")
+	clone.Add("email", "bad")
+	clone["name"][0] = "changed"
+
+	want := Errors{"name": {"first"}}
+	if !reflect.DeepEqual(original, want) {
+		t.Fatalf("original = %v after mutating clone, want %v", original, want)
+	}
+	if got := original["name"][:cap(original["name"])][1]; got != "" {
+		t.Errorf("original's spare capacity was written through the clone: %q", got)
+	}
+	if !reflect.DeepEqual(clone, Errors{"name": {"changed", "second"}, "email": {"bad"}}) {
+		t.Errorf("clone = %v", clone)
+	}
+}
+
+func TestResultWarningsSkipOnError(t *testing.T) {
+	var reported []string
+	OnError = func(field, rule, msg string) { reported = append(reported, rule) }
+	defer func() { OnError = nil }()
+
+	r := NewResult()
+	IsStringLength("password", r.Errors, "abc", 8, 128)
+	WarnStringMinLength("password", r.Warnings, "abc", 12)
+	WarnStringLength("name", r.Warnings, "abc", 4, 10)
+	r.AddWarning("email", "Looks like a typo")
+
+	if want := []string{"string_length"}; !reflect.DeepEqual(reported, want) {
+		t.Errorf("OnError reported %v, want %v", reported, want)
+	}
+
+	want := Errors{
+		"password": {"Must be at least 12 characters long"},
+		"name":     {"Must be between 4 and 10 characters long"},
+		"email":    {"Looks like a typo"},
+	}
+	if !reflect.DeepEqual(r.Warnings, want) {
+		t.Errorf("Warnings = %v, want %v", r.Warnings, want)
+	}
+	if !r.HasErrors() || !r.HasWarnings() {
+		t.Errorf("HasErrors = %v, HasWarnings = %v, want both true", r.HasErrors(), r.HasWarnings())
+	}
+}
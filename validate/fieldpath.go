@@ -0,0 +1,48 @@
+package validate
+
+import "strconv"
+
+// Nested field keys follow one convention, so frontends can parse them to find
+// the exact input: object members are joined with "." and slice entries are
+// indexed with "[i]", as in "items[2].price" or "metadata.tags[0]".
+
+// PathJoin returns the key for the member child within parent, such as
+// "address.city".  An empty parent gives child alone.
+func PathJoin(parent string, child string) string {
+	if parent == "" {
+		return child
+	}
+	if child == "" {
+		return parent
+	}
+	return parent + "." + child
+}
+
+// PathIndex returns the key for entry i of the slice at parent, such as
+// "items[2]".
+func PathIndex(parent string, i int) string {
+	return parent + "[" + strconv.Itoa(i) + "]"
+}
+
+// ForEach runs fn against each entry of v, with the entry's key built by
+// PathIndex, so errors for the third item land on "items[2]".
+func ForEach[T any](
+	field string,
+	errors Errors,
+	v []T,
+	fn func(field string, errors Errors, val T),
+) {
+	for i, val := range v {
+		fn(PathIndex(field, i), errors, val)
+	}
+}
+
+// MergeWithPrefix records every message from sub on the receiver, keeping
+// sub's field keys but nesting them under prefix with PathJoin, so "city" in
+// sub becomes "address.city".  Unlike FoldInto, the individual keys are kept.
+func (e Errors) MergeWithPrefix(prefix string, sub Errors) {
+	for k, msgs := range sub {
+		key := PathJoin(prefix, k)
+		e[key] = append(e[key], msgs...)
+	}
+}
@@ -0,0 +1,73 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPathHelpers(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{PathJoin("address", "city"), "address.city"},
+		{PathJoin("", "city"), "city"},
+		{PathJoin("address", ""), "address"},
+		{PathIndex("items", 2), "items[2]"},
+		{PathIndex("", 0), "[0]"},
+		{PathJoin(PathIndex("items", 2), "price"), "items[2].price"},
+		{PathIndex(PathJoin("metadata", "tags"), 0), "metadata.tags[0]"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestNestedPaths(t *testing.T) {
+	type item struct {
+		Price int
+		Tags  []string
+	}
+	order := struct {
+		Items []item
+		Tags  []string
+	}{
+		Items: []item{
+			{Price: 5},
+			{Price: 10, Tags: []string{"ok"}},
+			{Price: -1, Tags: []string{"ok", ""}},
+		},
+		Tags: []string{"", "ok"},
+	}
+
+	errs := Errors{}
+	ForEach("items", errs, order.Items, func(field string, errors Errors, it item) {
+		IsNumberBetween(PathJoin(field, "price"), errors, it.Price, 0, 100)
+		ForEach(PathJoin(field, "tags"), errors, it.Tags, IsNotEmpty)
+	})
+
+	// The metadata section is validated separately and merged in.
+	metadata := Errors{}
+	ForEach("tags", metadata, order.Tags, IsNotEmpty)
+	errs.MergeWithPrefix("metadata", metadata)
+
+	want := Errors{
+		"items[2].price":   {"Must be between 0 and 100, but was -1"},
+		"items[2].tags[1]": {"Must not be empty"},
+		"metadata.tags[0]": {"Must not be empty"},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors = %v, want %v", errs, want)
+	}
+}
+
+func TestMergeWithPrefixAppends(t *testing.T) {
+	errs := Errors{"address.city": {"first"}}
+	errs.MergeWithPrefix("address", Errors{"city": {"second"}, "": {"whole"}})
+
+	want := Errors{"address.city": {"first", "second"}, "address": {"whole"}}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("errors = %v, want %v", errs, want)
+	}
+}
@@ -0,0 +1,109 @@
+package validate
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var subdomainRx = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// IsAvailableSubdomain Checks that the value is a valid lowercase DNS label,
+// of 1 to 63 letters, digits and hyphens with no leading or trailing hyphen,
+// and that it isn't one of the reserved names, such as "www" or "admin",
+// compared case-insensitively.
+func IsAvailableSubdomain(
+	field string,
+	errors Errors,
+	v string,
+	reserved []string,
+) {
+	if !subdomainRx.MatchString(v) {
+		addError("subdomain", field, errors, "Must be a valid subdomain")
+		return
+	}
+
+	for _, r := range reserved {
+		if strings.EqualFold(v, r) {
+			addError("subdomain", field, errors, "This subdomain is reserved")
+			return
+		}
+	}
+}
+
+// IsHostOrIP Checks that the value is an IPv4 address, an IPv6 address or an
+// RFC 1123 hostname, as entered into a "connect to" field.  A hostname whose
+// last label is all digits is rejected, so a malformed IPv4 address such as
+// "999.1.1.1" isn't accepted as a hostname instead.
+func IsHostOrIP(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if !validHostOrIP(v) {
+		addError("host_or_ip", field, errors, "Must be a valid host or IP address")
+	}
+}
+
+// IsHostOrIPPort is like IsHostOrIP, but also accepts a ":port" suffix, with
+// an IPv6 address then written in brackets, as in "[::1]:8080".  The port
+// must be a number from 1 to 65535.
+func IsHostOrIPPort(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if validHostOrIP(v) {
+		return
+	}
+
+	host, port, err := net.SplitHostPort(v)
+	if err != nil || !validHostOrIP(host) {
+		addError("host_or_ip", field, errors, "Must be a valid host or IP address")
+		return
+	}
+	if !validPort(port) {
+		addError("host_or_ip", field, errors, "Must be a valid port")
+	}
+}
+
+// IsPort Checks that the value is a TCP or UDP port number from 1 to 65535.
+func IsPort(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if !validPort(v) {
+		addError("port", field, errors, "Must be a valid port")
+	}
+}
+
+func validPort(v string) bool {
+	if !isDigits(v) {
+		return false
+	}
+	p, err := strconv.Atoi(v)
+	return err == nil && p >= 1 && p <= 65535
+}
+
+func validHostOrIP(v string) bool {
+	return net.ParseIP(v) != nil || validHostname(v)
+}
+
+// validHostname reports whether v is an RFC 1123 hostname of at most 253
+// characters, optionally ending in a dot, whose last label isn't all digits.
+func validHostname(v string) bool {
+	v = strings.TrimSuffix(v, ".")
+	if len(v) == 0 || len(v) > 253 {
+		return false
+	}
+
+	labels := strings.Split(v, ".")
+	for _, label := range labels {
+		if !validHostLabel(label) {
+			return false
+		}
+	}
+	return !isDigits(labels[len(labels)-1])
+}
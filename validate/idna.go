@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+// Punycode parameters from RFC 3492.
+const (
+	punyBase        = 36
+	punyTMin        = 1
+	punyTMax        = 26
+	punySkew        = 38
+	punyDamp        = 700
+	punyInitialBias = 72
+	punyInitialN    = 128
+)
+
+var errInvalidDomain = errors.New("validate: invalid domain")
+
+// IsEmailIDN Checks that the value is an email address whose domain, once
+// converted to its ASCII form with DomainToASCII, is a valid hostname.  Unlike
+// IsEmail it checks the domain's structure, while still accepting
+// internationalized domains such as "user@münchen.de".
+//
+// Internationalized domains allow homographs, such as a Cyrillic "а" in place
+// of a Latin "a", which are a known phishing vector.  This validator does not
+// guard against them.
+func IsEmailIDN(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if _, err := NormalizeEmailDomain(v); err != nil {
+		addError("email_idn", field, errors, "Email address is invalid")
+	}
+}
+
+// NormalizeEmailDomain returns the email address with its domain converted to
+// ASCII by DomainToASCII, so "user@München.de" becomes
+// "user@xn--mnchen-3ya.de".  Storing this form means the same mailbox always
+// compares equal.  An error is returned if the address or domain is invalid.
+func NormalizeEmailDomain(v string) (string, error) {
+	at := strings.LastIndex(v, "@")
+	if at < 1 || strings.IndexFunc(v[:at], unicode.IsSpace) >= 0 {
+		return "", errInvalidDomain
+	}
+
+	domain, err := DomainToASCII(v[at+1:])
+	if err != nil {
+		return "", err
+	}
+	return v[:at+1] + domain, nil
+}
+
+// DomainToASCII converts a domain to its ASCII form, lowercasing it and
+// punycode-encoding each label that contains non-ASCII characters, and checks
+// the result is a valid hostname.  This is a minimal form of IDNA: the
+// ideographic full stops are accepted as label separators, but Unicode
+// normalization and the full UTS #46 mapping are not applied.
+func DomainToASCII(domain string) (string, error) {
+	domain = strings.Map(func(r rune) rune {
+		switch r {
+		case '。', '．', '｡':
+			return '.'
+		}
+		return r
+	}, domain)
+
+	labels := strings.Split(strings.ToLower(domain), ".")
+	for i, label := range labels {
+		if !isASCII(label) {
+			encoded, err := punycodeEncode([]rune(label))
+			if err != nil {
+				return "", err
+			}
+			label = "xn--" + encoded
+			labels[i] = label
+		}
+		if !validHostLabel(label) {
+			return "", errInvalidDomain
+		}
+	}
+
+	ascii := strings.Join(labels, ".")
+	if len(ascii) > 253 {
+		return "", errInvalidDomain
+	}
+	return ascii, nil
+}
+
+// validHostLabel reports whether s is a 1 to 63 character hostname label of
+// letters, digits and hyphens, not starting or ending with a hyphen.
+func validHostLabel(s string) bool {
+	if len(s) == 0 || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// punycodeEncode encodes a label as described in RFC 3492, without the "xn--"
+// prefix.
+func punycodeEncode(input []rune) (string, error) {
+	var out []byte
+	for _, r := range input {
+		if r < 0x80 {
+			out = append(out, byte(r))
+		}
+	}
+	b := len(out)
+	h := b
+	if b > 0 {
+		out = append(out, '-')
+	}
+
+	n, delta, bias := rune(punyInitialN), 0, punyInitialBias
+	for h < len(input) {
+		m := rune(unicode.MaxRune + 1)
+		for _, r := range input {
+			if r >= n && r < m {
+				m = r
+			}
+		}
+
+		delta += int(m-n) * (h + 1)
+		if delta < 0 {
+			return "", errInvalidDomain
+		}
+		n = m
+
+		for _, r := range input {
+			if r < n {
+				delta++
+			}
+			if r != n {
+				continue
+			}
+
+			q := delta
+			for k := punyBase; ; k += punyBase {
+				t := k - bias
+				if t < punyTMin {
+					t = punyTMin
+				} else if t > punyTMax {
+					t = punyTMax
+				}
+				if q < t {
+					break
+				}
+				out = append(out, punyDigit(t+(q-t)%(punyBase-t)))
+				q = (q - t) / (punyBase - t)
+			}
+			out = append(out, punyDigit(q))
+
+			bias = punyAdapt(delta, h+1, h == b)
+			delta = 0
+			h++
+		}
+
+		delta++
+		n++
+	}
+
+	return string(out), nil
+}
+
+func punyDigit(d int) byte {
+	if d < 26 {
+		return byte('a' + d)
+	}
+	return byte('0' + d - 26)
+}
+
+func punyAdapt(delta, numPoints int, first bool) int {
+	if first {
+		delta /= punyDamp
+	} else {
+		delta /= 2
+	}
+	delta += delta / numPoints
+
+	k := 0
+	for delta > ((punyBase-punyTMin)*punyTMax)/2 {
+		delta /= punyBase - punyTMin
+		k += punyBase
+	}
+	return k + (punyBase-punyTMin+1)*delta/(delta+punySkew)
+}
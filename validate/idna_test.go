@@ -0,0 +1,39 @@
+package validate
+
+import "testing"
+
+func TestNormalizeEmailDomain(t *testing.T) {
+	tests := map[string]string{
+		"user@münchen.de":     "user@xn--mnchen-3ya.de",
+		"user@München.DE":     "user@xn--mnchen-3ya.de",
+		"a@bücher.example":    "a@xn--bcher-kva.example",
+		"a@例え.テスト":            "a@xn--r8jz45g.xn--zckzah",
+		"a@xn--mnchen-3ya.de": "a@xn--mnchen-3ya.de",
+		"Bob@Example.com":     "Bob@example.com",
+	}
+
+	for in, want := range tests {
+		got, err := NormalizeEmailDomain(in)
+		if err != nil || got != want {
+			t.Errorf("NormalizeEmailDomain(%q) = %q, %v, want %q", in, got, err, want)
+		}
+	}
+}
+
+func TestIsEmailIDN(t *testing.T) {
+	for _, v := range []string{"user@münchen.de", "a@例え.テスト", "plain@example.com"} {
+		errs := Errors{}
+		IsEmailIDN("email", errs, v)
+		if len(errs) != 0 {
+			t.Errorf("IsEmailIDN(%q) = %v, want no errors", v, errs)
+		}
+	}
+
+	for _, v := range []string{"@münchen.de", "a b@x.com", "a@", "a@-x.com", "a@x..com", "a@x_y.com"} {
+		errs := Errors{}
+		IsEmailIDN("email", errs, v)
+		if got := errs["email"]; len(got) != 1 || got[0] != "Email address is invalid" {
+			t.Errorf("IsEmailIDN(%q) = %v, want an invalid email error", v, errs)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// IsJSONWithKeys Checks that the value is a JSON object containing each of the
+// required keys, recording one error per missing key.  A key may be a dotted
+// path such as "db.host" to require a key within a nested object.
+func IsJSONWithKeys(
+	field string,
+	errors Errors,
+	v string,
+	requiredKeys ...string,
+) {
+	var doc any
+	if err := json.Unmarshal([]byte(v), &doc); err != nil {
+		addError("json_keys", field, errors, "Must be valid JSON")
+		return
+	}
+
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		addError("json_keys", field, errors, "Must be a JSON object")
+		return
+	}
+
+	for _, key := range requiredKeys {
+		if !hasJSONPath(obj, strings.Split(key, ".")) {
+			addError("json_keys", field, errors, fmt.Sprintf("Missing key: %s", key))
+		}
+	}
+}
+
+func hasJSONPath(obj map[string]any, path []string) bool {
+	v, ok := obj[path[0]]
+	if !ok {
+		return false
+	}
+	if len(path) == 1 {
+		return true
+	}
+
+	child, ok := v.(map[string]any)
+	return ok && hasJSONPath(child, path[1:])
+}
@@ -0,0 +1,26 @@
+package validate
+
+import "context"
+
+// IsUnique Checks that the value isn't already taken, such as an email that is
+// already registered, by calling the caller-supplied exists lookup.  Keeping
+// the lookup injected leaves this package storage-agnostic.  A failed lookup
+// is not a validation error: it is returned, and nothing is recorded against
+// the field.
+func IsUnique(
+	ctx context.Context,
+	field string,
+	errors Errors,
+	v string,
+	exists func(ctx context.Context, value string) (bool, error),
+) error {
+	taken, err := exists(ctx, v)
+	if err != nil {
+		return err
+	}
+
+	if taken {
+		addError("unique", field, errors, "This value is already taken")
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// currencyExponents maps each active ISO 4217 currency code to the number of
+// decimal places in its minor unit.
+var currencyExponents = map[string]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ANG": 2, "AOA": 2, "ARS": 2,
+	"AUD": 2, "AWG": 2, "AZN": 2, "BAM": 2, "BBD": 2, "BDT": 2, "BGN": 2,
+	"BHD": 3, "BIF": 0, "BMD": 2, "BND": 2, "BOB": 2, "BOV": 2, "BRL": 2,
+	"BSD": 2, "BTN": 2, "BWP": 2, "BYN": 2, "BZD": 2, "CAD": 2, "CDF": 2,
+	"CHE": 2, "CHF": 2, "CHW": 2, "CLF": 4, "CLP": 0, "CNY": 2, "COP": 2,
+	"COU": 2, "CRC": 2, "CUP": 2, "CVE": 2, "CZK": 2, "DJF": 0, "DKK": 2,
+	"DOP": 2, "DZD": 2, "EGP": 2, "ERN": 2, "ETB": 2, "EUR": 2, "FJD": 2,
+	"FKP": 2, "GBP": 2, "GEL": 2, "GHS": 2, "GIP": 2, "GMD": 2, "GNF": 0,
+	"GTQ": 2, "GYD": 2, "HKD": 2, "HNL": 2, "HTG": 2, "HUF": 2, "IDR": 2,
+	"ILS": 2, "INR": 2, "IQD": 3, "IRR": 2, "ISK": 0, "JMD": 2, "JOD": 3,
+	"JPY": 0, "KES": 2, "KGS": 2, "KHR": 2, "KMF": 0, "KPW": 2, "KRW": 0,
+	"KWD": 3, "KYD": 2, "KZT": 2, "LAK": 2, "LBP": 2, "LKR": 2, "LRD": 2,
+	"LSL": 2, "LYD": 3, "MAD": 2, "MDL": 2, "MGA": 2, "MKD": 2, "MMK": 2,
+	"MNT": 2, "MOP": 2, "MRU": 2, "MUR": 2, "MVR": 2, "MWK": 2, "MXN": 2,
+	"MXV": 2, "MYR": 2, "MZN": 2, "NAD": 2, "NGN": 2, "NIO": 2, "NOK": 2,
+	"NPR": 2, "NZD": 2, "OMR": 3, "PAB": 2, "PEN": 2, "PGK": 2, "PHP": 2,
+	"PKR": 2, "PLN": 2, "PYG": 0, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2,
+	"RWF": 0, "SAR": 2, "SBD": 2, "SCR": 2, "SDG": 2, "SEK": 2, "SGD": 2,
+	"SHP": 2, "SLE": 2, "SOS": 2, "SRD": 2, "SSP": 2, "STN": 2, "SVC": 2,
+	"SYP": 2, "SZL": 2, "THB": 2, "TJS": 2, "TMT": 2, "TND": 3, "TOP": 2,
+	"TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0, "USD": 2,
+	"USN": 2, "UYI": 0, "UYU": 2, "UYW": 4, "UZS": 2, "VED": 2, "VES": 2,
+	"VND": 0, "VUV": 0, "WST": 2, "XAF": 0, "XCD": 2, "XCG": 2, "XOF": 0,
+	"XPF": 0, "YER": 2, "ZAR": 2, "ZMW": 2, "ZWG": 2,
+}
+
+// CurrencyExponent returns the number of decimal places in the minor unit of
+// the ISO 4217 currency code, such as 2 for USD or 0 for JPY, and whether the
+// code is known.  The code is matched case-insensitively.
+func CurrencyExponent(code string) (int, bool) {
+	exp, ok := currencyExponents[strings.ToUpper(code)]
+	return exp, ok
+}
+
+var moneyAmountRx = regexp.MustCompile(`^(-?)(\d+)(?:\.(\d+))?$`)
+
+var (
+	errUnknownCurrency = errors.New("validate: unknown currency")
+	errMalformedAmount = errors.New("validate: malformed amount")
+	errAmountPrecision = errors.New("validate: amount not representable in currency")
+)
+
+// ParseMinorUnits converts a decimal amount such as "12.34" into an integer
+// count of the currency's own minor units, the form FormatMinorUnits and
+// IsAmountInRange work with: "12.34" USD is 1234, "1200" JPY is 1200 and
+// "1.234" BHD is 1234.  Trailing zeros in the fraction are ignored, so "12.00"
+// JPY is 12, but any other fraction finer than the currency's minor unit, such
+// as "12.5" JPY, is an error, as are unknown currencies and amounts too large
+// for an int64.
+func ParseMinorUnits(amount string, currency string) (int64, error) {
+	exp, ok := CurrencyExponent(currency)
+	if !ok {
+		return 0, errUnknownCurrency
+	}
+
+	m := moneyAmountRx.FindStringSubmatch(amount)
+	if m == nil {
+		return 0, errMalformedAmount
+	}
+
+	frac := strings.TrimRight(m[3], "0")
+	if len(frac) > exp {
+		return 0, errAmountPrecision
+	}
+
+	n, err := strconv.ParseInt(m[1]+m[2]+frac+strings.Repeat("0", exp-len(frac)), 10, 64)
+	if err != nil {
+		return 0, errAmountPrecision
+	}
+	return n, nil
+}
+
+// IsMoneyAmount Checks that currency is a known ISO 4217 code and that amount,
+// a decimal string such as "12.34", has no more decimal places than the
+// currency's minor unit allows, as tested by ParseMinorUnits.  So "12.50" is
+// rejected for JPY, which has no minor unit, while "1.234" is accepted for
+// BHD, which has three decimal places.
+func IsMoneyAmount(
+	field string,
+	errors Errors,
+	currency string,
+	amount string,
+) {
+	switch _, err := ParseMinorUnits(amount, currency); err {
+	case nil:
+	case errUnknownCurrency:
+		addError("money_amount", field, errors, "Must be a valid currency code")
+	case errMalformedAmount:
+		addError("money_amount", field, errors, "Must be a valid amount")
+	default:
+		addError("money_amount", field, errors, fmt.Sprintf("Amount is not valid for currency %s", strings.ToUpper(currency)))
+	}
+}
+
+// currencySymbols holds the symbol FormatMinorUnits uses for common
+// currencies.  Others are written with their code, as in "CHF 12.34".
+var currencySymbols = map[string]string{
+	"AUD": "A$", "CAD": "CA$", "CNY": "CN¥", "EUR": "€", "GBP": "£",
+	"HKD": "HK$", "INR": "₹", "JPY": "¥", "KRW": "₩", "MXN": "MX$",
+	"NZD": "NZ$", "USD": "$",
+}
+
+// IsAmountInRange Checks that a monetary amount in the currency's own integer
+// minor units, as returned by ParseMinorUnits, lies between m and n
+// (inclusive), recording the message otherwise.  Working in integers keeps the
+// comparison exact; FormatMinorUnits can render the bounds for the message.
+func IsAmountInRange(
+	field string,
+	errors Errors,
+	v int64,
+	m int64,
+	n int64,
+	message string,
+) {
+	between("amount_range", field, errors, v, m, n, message)
+}
+
+// FormatMinorUnits formats an amount given in the currency's own minor units
+// for display, such as "$12.34" for 1234 USD, "¥1234" for 1234 JPY or
+// "-BHD 1.234" for -1234 BHD.  Unknown currencies are assumed to have two
+// decimal places.
+func FormatMinorUnits(amount int64, currency string) string {
+	code := strings.ToUpper(currency)
+	exp, ok := CurrencyExponent(code)
+	if !ok {
+		exp = 2
+	}
+
+	prefix := code + " "
+	if sym, ok := currencySymbols[code]; ok {
+		prefix = sym
+	}
+
+	sign := ""
+	// Split the magnitude as uint64 so math.MinInt64 doesn't overflow.
+	mag := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		mag = -mag
+	}
+
+	if exp == 0 {
+		return fmt.Sprintf("%s%s%d", sign, prefix, mag)
+	}
+
+	var div uint64 = 1
+	for i := 0; i < exp; i++ {
+		div *= 10
+	}
+	return fmt.Sprintf("%s%s%d.%0*d", sign, prefix, mag/div, exp, mag%div)
+}
@@ -0,0 +1,65 @@
+package validate
+
+import "testing"
+
+func TestIsMoneyAmount(t *testing.T) {
+	tests := []struct {
+		currency string
+		amount   string
+		want     string
+	}{
+		{"JPY", "1200", ""},
+		{"JPY", "1200.00", ""},
+		{"JPY", "12.5", "Amount is not valid for currency JPY"},
+		{"USD", "12.34", ""},
+		{"usd", "12.3", ""},
+		{"USD", "0.001", "Amount is not valid for currency USD"},
+		{"USD", "-5.00", ""},
+		{"BHD", "1.234", ""},
+		{"BHD", "1.2345", "Amount is not valid for currency BHD"},
+		{"USD", "12.", "Must be a valid amount"},
+		{"USD", "$12", "Must be a valid amount"},
+		{"USD", "", "Must be a valid amount"},
+		{"USD", "99999999999999999999", "Amount is not valid for currency USD"},
+		{"XYZ", "1", "Must be a valid currency code"},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsMoneyAmount("price", errs, tt.currency, tt.amount)
+
+		got := ""
+		if msgs := errs["price"]; len(msgs) > 0 {
+			got = msgs[0]
+		}
+		if got != tt.want {
+			t.Errorf("IsMoneyAmount(%q, %q) = %q, want %q", tt.currency, tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestParseMinorUnitsRoundTrip(t *testing.T) {
+	tests := []struct {
+		currency  string
+		amount    string
+		minor     int64
+		formatted string
+	}{
+		{"JPY", "1200", 1200, "¥1200"},
+		{"USD", "12.34", 1234, "$12.34"},
+		{"USD", "-0.05", -5, "-$0.05"},
+		{"BHD", "1.234", 1234, "BHD 1.234"},
+		{"BHD", "1.2", 1200, "BHD 1.200"},
+	}
+
+	for _, tt := range tests {
+		minor, err := ParseMinorUnits(tt.amount, tt.currency)
+		if err != nil || minor != tt.minor {
+			t.Errorf("ParseMinorUnits(%q, %q) = %d, %v, want %d", tt.amount, tt.currency, minor, err, tt.minor)
+			continue
+		}
+		if got := FormatMinorUnits(minor, tt.currency); got != tt.formatted {
+			t.Errorf("FormatMinorUnits(%d, %q) = %q, want %q", minor, tt.currency, got, tt.formatted)
+		}
+	}
+}
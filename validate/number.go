@@ -0,0 +1,209 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IsIntegerInRange Checks that a string, such as a submitted form value,
+// parses as an integer between m and n (inclusive).  Only one error is
+// recorded: either that it isn't a number, or that it is out of range.
+func IsIntegerInRange(
+	field string,
+	errors Errors,
+	v string,
+	m int,
+	n int,
+) {
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		addError("integer_range", field, errors, "Must be a number")
+		return
+	}
+
+	between("integer_range", field, errors, i, m, n, fmt.Sprintf("Must be between %d and %d", m, n))
+}
+
+// IsFloatInRange Checks that a string parses as a finite number between m and
+// n (inclusive), recording at most one error like IsIntegerInRange.
+func IsFloatInRange(
+	field string,
+	errors Errors,
+	v string,
+	m float64,
+	n float64,
+) {
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		addError("float_range", field, errors, "Must be a number")
+		return
+	}
+
+	between("float_range", field, errors, f, m, n, fmt.Sprintf("Must be between %g and %g", m, n))
+}
+
+// IsNumberInSet Checks that the number is one of the allowed values, listing
+// them in the message, such as "Must be one of: 1, 5, 10, but was 3".
+func IsNumberInSet[T NumericComparable](
+	field string,
+	errors Errors,
+	v T,
+	allowed ...T,
+) {
+	list := make([]string, len(allowed))
+	for i, a := range allowed {
+		list[i] = fmt.Sprintf("%d", a)
+	}
+
+	matchesAny("number_in_set", field, errors, v, allowed, fmt.Sprintf("Must be one of: %s, but was %d", strings.Join(list, ", "), v))
+}
+
+// IntList parses a comma-separated list of integers such as "1, 2,3",
+// allowing spaces around each entry.  An empty string is an empty list.  The
+// error names the first entry, counting from 1, that isn't a number.
+func IntList(v string) ([]int, error) {
+	parts := intListEntries(v)
+	ints := make([]int, len(parts))
+	for i, p := range parts {
+		n, err := intListEntry(p)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d is not a number", i+1)
+		}
+		ints[i] = n
+	}
+	return ints, nil
+}
+
+// IsIntList Checks that a string is a comma-separated list of at most maxCount
+// integers, as parsed by IntList.  An error is recorded for every entry that
+// isn't a number, counting from 1, as well as for too many entries.
+func IsIntList(
+	field string,
+	errors Errors,
+	v string,
+	maxCount int,
+) {
+	parts := intListEntries(v)
+	if len(parts) > maxCount {
+		addError("int_list", field, errors, fmt.Sprintf("Must have at most %d entries, but had %d", maxCount, len(parts)))
+	}
+
+	for i, p := range parts {
+		if _, err := intListEntry(p); err != nil {
+			addError("int_list", field, errors, fmt.Sprintf("Entry %d is not a number", i+1))
+		}
+	}
+}
+
+// intListEntries splits v into the raw entries shared by IntList and
+// IsIntList, with nil for an empty or blank string.
+func intListEntries(v string) []string {
+	if strings.TrimSpace(v) == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// intListEntry parses one entry from intListEntries.
+func intListEntry(p string) (int, error) {
+	return strconv.Atoi(strings.TrimSpace(p))
+}
+
+// MaxSafeInteger is the largest integer a float64, and so a JSON number
+// decoded by most parsers, can hold exactly: 2^53 - 1.
+const MaxSafeInteger = 1<<53 - 1
+
+// IsSafeInteger Checks that a number decoded from JSON is a whole number
+// within ±MaxSafeInteger, beyond which neighbouring integers can no longer be
+// told apart and an ID may be silently changed, as 9007199254740993 becomes
+// 9007199254740992.  Large IDs are better sent as JSON strings.
+func IsSafeInteger(
+	field string,
+	errors Errors,
+	v float64,
+) {
+	if math.Abs(v) > MaxSafeInteger || v != math.Trunc(v) {
+		addError("safe_integer", field, errors, "Number exceeds safe integer range")
+	}
+}
+
+var quantityRx = regexp.MustCompile(`^([+-]?(?:\d+(?:\.\d*)?|\.\d+))\s*(\S*)$`)
+
+// ParseQuantity splits a measurement such as "10kg", "3.5 L" or "500 ml" into
+// its number and unit, allowing a space between them.  The unit is "" when
+// none is given.  The number may be negative or zero; IsQuantity rejects
+// those.
+func ParseQuantity(v string) (float64, string, error) {
+	m := quantityRx.FindStringSubmatch(strings.TrimSpace(v))
+	if m == nil {
+		return 0, "", fmt.Errorf("validate: %q is not a quantity", v)
+	}
+
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, m[2], nil
+}
+
+// IsQuantity Checks that the value is a positive number followed by a unit
+// from allowedUnits, compared case-insensitively, as parsed by ParseQuantity.
+func IsQuantity(
+	field string,
+	errors Errors,
+	v string,
+	allowedUnits ...string,
+) {
+	n, unit, err := ParseQuantity(v)
+	if err != nil || n <= 0 {
+		addError("quantity", field, errors, "Must be a positive quantity")
+		return
+	}
+
+	if unit == "" {
+		addError("quantity", field, errors, "Must include a unit")
+		return
+	}
+	for _, u := range allowedUnits {
+		if strings.EqualFold(unit, u) {
+			return
+		}
+	}
+	addError("quantity", field, errors, fmt.Sprintf("Unknown unit %q", unit))
+}
+
+// ratingEpsilon is the tolerance IsRating allows, relative to the number of
+// steps, for floating point error such as 0.1+0.2 != 0.3.
+const ratingEpsilon = 1e-9
+
+// IsRating Checks that v lies between m and n (inclusive) and is a whole
+// number of steps from m, such as 0 to 5 stars in steps of 0.5.  A small
+// tolerance absorbs floating point error, so 0.1+0.2 is accepted in steps of
+// 0.1, while 3.3 is still rejected in steps of 0.5.  step must be positive; a
+// step of zero or less allows no ratings, so every value is rejected.
+func IsRating(
+	field string,
+	errors Errors,
+	v float64,
+	m float64,
+	n float64,
+	step float64,
+) {
+	msg := fmt.Sprintf("Must be between %v and %v in steps of %v", m, n, step)
+	if !(step > 0) {
+		addError("rating", field, errors, msg)
+		return
+	}
+
+	steps := (v - m) / step
+	nearest := math.Round(steps)
+	tolerance := ratingEpsilon * math.Max(1, math.Abs(steps))
+
+	inRange := v >= m-tolerance*step && v <= n+tolerance*step
+	if !inRange || math.Abs(steps-nearest) > tolerance {
+		addError("rating", field, errors, msg)
+	}
+}
@@ -0,0 +1,158 @@
+package validate
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestIsIntegerInRangeBounds(t *testing.T) {
+	rangeMsg := "Must be between 1 and 10"
+	tests := map[string][]string{
+		"0":    {rangeMsg},
+		"1":    nil,
+		" 10 ": nil,
+		"11":   {rangeMsg},
+		"":     {"Must be a number"},
+		"1.5":  {"Must be a number"},
+		"ten":  {"Must be a number"},
+	}
+	for v, want := range tests {
+		errs := Errors{}
+		IsIntegerInRange("f", errs, v, 1, 10)
+		assertErrors(t, errs, "f", want...)
+	}
+
+	for v, want := range map[string][]string{
+		"4": {"Must be between 5 and 5"},
+		"5": nil,
+		"6": {"Must be between 5 and 5"},
+	} {
+		errs := Errors{}
+		IsIntegerInRange("f", errs, v, 5, 5)
+		assertErrors(t, errs, "f", want...)
+	}
+}
+
+func TestIsFloatInRangeBounds(t *testing.T) {
+	rangeMsg := "Must be between 0.5 and 2.5"
+	tests := map[string][]string{
+		"0.49": {rangeMsg},
+		"0.5":  nil,
+		"2.5":  nil,
+		"2.51": {rangeMsg},
+		"NaN":  {"Must be a number"},
+		"Inf":  {"Must be a number"},
+		"abc":  {"Must be a number"},
+	}
+	for v, want := range tests {
+		errs := Errors{}
+		IsFloatInRange("f", errs, v, 0.5, 2.5)
+		assertErrors(t, errs, "f", want...)
+	}
+
+	for v, want := range map[string][]string{
+		"0.9": {"Must be between 1 and 1"},
+		"1":   nil,
+		"1.1": {"Must be between 1 and 1"},
+	} {
+		errs := Errors{}
+		IsFloatInRange("f", errs, v, 1, 1)
+		assertErrors(t, errs, "f", want...)
+	}
+}
+
+func TestIsSafeInteger(t *testing.T) {
+	var beyond float64
+	if err := json.Unmarshal([]byte("9007199254740993"), &beyond); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		v     float64
+		valid bool
+	}{
+		{"max safe", MaxSafeInteger, true},
+		{"min safe", -MaxSafeInteger, true},
+		{"zero", 0, true},
+		{"decoded 2^53+1", beyond, false},
+		{"2^53", 1 << 53, false},
+		{"fraction", 1.5, false},
+		{"NaN", math.NaN(), false},
+		{"infinity", math.Inf(-1), false},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsSafeInteger("id", errs, tt.v)
+		if got := len(errs) == 0; got != tt.valid {
+			t.Errorf("%s: IsSafeInteger(%v) valid = %v, want %v", tt.name, tt.v, got, tt.valid)
+		}
+	}
+}
+
+func TestIntListAgreesWithIsIntList(t *testing.T) {
+	tests := []struct {
+		v    string
+		want []int
+		errs []string
+	}{
+		{"", []int{}, nil},
+		{"  ", []int{}, nil},
+		{"1, 2,3", []int{1, 2, 3}, nil},
+		{" -4 ", []int{-4}, nil},
+		{"1,x,3", nil, []string{"Entry 2 is not a number"}},
+		{"1,,3", nil, []string{"Entry 2 is not a number"}},
+		{"a,b", nil, []string{"Entry 1 is not a number", "Entry 2 is not a number"}},
+	}
+
+	for _, tt := range tests {
+		got, err := IntList(tt.v)
+		if (err != nil) != (tt.errs != nil) {
+			t.Errorf("IntList(%q) error = %v, want error %v", tt.v, err, tt.errs != nil)
+		}
+		if err == nil && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("IntList(%q) = %v, want %v", tt.v, got, tt.want)
+		}
+
+		errs := Errors{}
+		IsIntList("ids", errs, tt.v, 10)
+		if !reflect.DeepEqual(errs["ids"], tt.errs) {
+			t.Errorf("IsIntList(%q) = %v, want %v", tt.v, errs["ids"], tt.errs)
+		}
+	}
+
+	errs := Errors{}
+	IsIntList("ids", errs, "1,2,3", 2)
+	if want := []string{"Must have at most 2 entries, but had 3"}; !reflect.DeepEqual(errs["ids"], want) {
+		t.Errorf("IsIntList over maxCount = %v, want %v", errs["ids"], want)
+	}
+}
+
+func TestIsRating(t *testing.T) {
+	tests := []struct {
+		v, m, n, step float64
+		valid         bool
+	}{
+		{0, 0, 5, 0.5, true},
+		{2.5, 0, 5, 0.5, true},
+		{5, 0, 5, 0.5, true},
+		{0.1 + 0.2, 0, 1, 0.1, true},
+		{3.3, 0, 5, 0.5, false},
+		{5.5, 0, 5, 0.5, false},
+		{-0.5, 0, 5, 0.5, false},
+		{2.5, 0, 5, -0.5, false},
+		{2.5, 0, 5, 0, false},
+		{2.5, 0, 5, math.NaN(), false},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsRating("stars", errs, tt.v, tt.m, tt.n, tt.step)
+		if got := len(errs) == 0; got != tt.valid {
+			t.Errorf("IsRating(%v, %v, %v, %v) valid = %v, want %v", tt.v, tt.m, tt.n, tt.step, got, tt.valid)
+		}
+	}
+}
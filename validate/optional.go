@@ -0,0 +1,68 @@
+package validate
+
+import "strings"
+
+// IfPresent runs fn against the value v points to, but only when v is not
+// nil.  A nil pointer means the value was not provided, such as an optional
+// JSON field that was omitted, so it is skipped rather than treated as an
+// error.  For example:
+//
+//	IfPresent("age", errs, req.Age, func(f string, e Errors, a int) {
+//		IsNumberBetween(f, e, a, 0, 120)
+//	})
+func IfPresent[T any](
+	field string,
+	errors Errors,
+	v *T,
+	fn func(field string, errors Errors, val T),
+) {
+	if v != nil {
+		fn(field, errors, *v)
+	}
+}
+
+// IsEmailOrEmpty is IsEmail for an optional field: a value that is empty once
+// trimmed of whitespace passes, and anything else must be an email address.
+func IsEmailOrEmpty(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if strings.TrimSpace(v) != "" {
+		IsEmail(field, errors, v)
+	}
+}
+
+// IsURLOrEmpty is IsURL for an optional field, passing trimmed-empty values.
+func IsURLOrEmpty(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if strings.TrimSpace(v) != "" {
+		IsURL(field, errors, v)
+	}
+}
+
+// IsPhoneE164OrEmpty is IsPhoneE164 for an optional field, passing
+// trimmed-empty values.
+func IsPhoneE164OrEmpty(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if strings.TrimSpace(v) != "" {
+		IsPhoneE164(field, errors, v)
+	}
+}
+
+// IsUUIDOrEmpty is IsUUID for an optional field, passing trimmed-empty values.
+func IsUUIDOrEmpty(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if strings.TrimSpace(v) != "" {
+		IsUUID(field, errors, v)
+	}
+}
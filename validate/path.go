@@ -0,0 +1,153 @@
+package validate
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MaxFilenameLength is the longest filename, in bytes, accepted by
+// IsValidFilename.  It is the limit shared by most common filesystems.
+const MaxFilenameLength = 255
+
+var reservedFilenames = map[string]bool{
+	"CON": true, "PRN": true, "AUX": true, "NUL": true,
+	"COM1": true, "COM2": true, "COM3": true, "COM4": true, "COM5": true,
+	"COM6": true, "COM7": true, "COM8": true, "COM9": true,
+	"LPT1": true, "LPT2": true, "LPT3": true, "LPT4": true, "LPT5": true,
+	"LPT6": true, "LPT7": true, "LPT8": true, "LPT9": true,
+}
+
+// IsValidFilename Checks that the value is a single filename that is safe
+// across common filesystems.  It rejects empty names, names longer than
+// MaxFilenameLength bytes, path separators and the other characters Windows
+// disallows (< > : " / \ | ? *), control characters, leading or trailing dots
+// and spaces, and reserved Windows device names such as CON or NUL.1, in any
+// case.
+func IsValidFilename(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if !validFilename(v) {
+		addError("filename", field, errors, "Must be a valid filename")
+	}
+}
+
+func validFilename(v string) bool {
+	if len(v) == 0 || len(v) > MaxFilenameLength {
+		return false
+	}
+
+	if strings.ContainsAny(v, `<>:"/\|?*`) {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if v[i] < 0x20 || v[i] == 0x7f {
+			return false
+		}
+	}
+
+	first, last := v[0], v[len(v)-1]
+	if first == '.' || first == ' ' || last == '.' || last == ' ' {
+		return false
+	}
+
+	base, _, _ := strings.Cut(v, ".")
+	return !reservedFilenames[strings.ToUpper(base)]
+}
+
+// IsURLPath Checks that the value is a site-relative URL path, such as
+// "/account/settings?tab=email".  It must start with a single "/", use valid
+// percent-encoding, and contain no ".." segments, whether written literally or
+// encoded.  Absolute and protocol-relative URLs ("//evil.com", or
+// "/%2F%2Fevil.com" once decoded) are rejected, as are backslashes, literal or
+// encoded as "%5C", which some browsers treat as "/".  This makes it suitable
+// for redirect targets, where accepting another host would allow an open
+// redirect.
+func IsURLPath(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if !validURLPath(v) {
+		addError("url_path", field, errors, "Must be a valid path")
+	}
+}
+
+func validURLPath(v string) bool {
+	if !strings.HasPrefix(v, "/") || strings.HasPrefix(v, "//") {
+		return false
+	}
+	if strings.Contains(v, `\`) {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if v[i] < 0x20 || v[i] == 0x7f {
+			return false
+		}
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || u.Scheme != "" || u.Host != "" || strings.HasPrefix(u.Path, "//") {
+		return false
+	}
+	if strings.Contains(u.Path, `\`) {
+		return false
+	}
+
+	for _, seg := range strings.Split(u.Path, "/") {
+		if seg == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsRedirectSafe Checks that the value is a safe target for a "next" or
+// "returnTo" style redirect: either a site-relative path accepted by
+// IsURLPath, or an absolute http or https URL whose host is one of
+// allowedHosts, compared case-insensitively.  An allowed host without a port
+// matches any port.  Protocol-relative URLs, other schemes such as
+// "javascript:", URLs carrying credentials ("https://good.com@evil.com"),
+// backslashes and whitespace or control characters are all rejected.
+func IsRedirectSafe(
+	field string,
+	errors Errors,
+	v string,
+	allowedHosts ...string,
+) {
+	if !redirectSafe(v, allowedHosts) {
+		addError("redirect_safe", field, errors, "Redirect target is not allowed")
+	}
+}
+
+func redirectSafe(v string, allowedHosts []string) bool {
+	if strings.HasPrefix(v, "/") {
+		return validURLPath(v)
+	}
+
+	if strings.Contains(v, `\`) {
+		return false
+	}
+	for i := 0; i < len(v); i++ {
+		if v[i] <= 0x20 || v[i] == 0x7f {
+			return false
+		}
+	}
+
+	u, err := url.Parse(v)
+	if err != nil || u.User != nil || u.Host == "" {
+		return false
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return false
+	}
+
+	for _, h := range allowedHosts {
+		if strings.EqualFold(h, u.Host) || strings.EqualFold(h, u.Hostname()) {
+			return true
+		}
+	}
+	return false
+}
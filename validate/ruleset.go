@@ -0,0 +1,54 @@
+package validate
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Validator checks a submitted string value, recording any errors against the
+// field.  Most of the string validators in this package can be adapted with a
+// closure, for example:
+//
+//	func(field string, errors Errors, v string) {
+//		IsStringLength(field, errors, v, 12, 128)
+//	}
+type Validator func(field string, errors Errors, v string)
+
+// RuleSet is an ordered list of validators that together describe a reusable
+// field type, such as "email" or "strong_password".
+type RuleSet []Validator
+
+// Apply runs each validator in the set, in order, against the value.
+func (rs RuleSet) Apply(field string, errors Errors, v string) {
+	for _, rule := range rs {
+		rule(field, errors, v)
+	}
+}
+
+var (
+	ruleSetsMu sync.RWMutex
+	ruleSets   = map[string]RuleSet{}
+)
+
+// RegisterRuleSet registers the rules under name so they can be applied by
+// name with ApplyRuleSet.  Registering a name again replaces its rules.
+// Rule sets are usually registered once at startup.
+func RegisterRuleSet(name string, rules ...Validator) {
+	ruleSetsMu.Lock()
+	defer ruleSetsMu.Unlock()
+	ruleSets[name] = RuleSet(rules)
+}
+
+// ApplyRuleSet runs the rule set registered under name against the value.  It
+// panics if no rule set has that name, since that is a programming error
+// rather than a problem with the submitted value.
+func ApplyRuleSet(name string, field string, errors Errors, v string) {
+	ruleSetsMu.RLock()
+	rs, ok := ruleSets[name]
+	ruleSetsMu.RUnlock()
+
+	if !ok {
+		panic(fmt.Sprintf("validate: no rule set registered as %q", name))
+	}
+	rs.Apply(field, errors, v)
+}
@@ -0,0 +1,79 @@
+package validate
+
+import "fmt"
+
+// IsSorted Checks that the slice is in ascending order, allowing repeated
+// values.  The message gives the first index that is out of order.
+func IsSorted[T Ordered](
+	field string,
+	errors Errors,
+	v []T,
+) {
+	for i := 1; i < len(v); i++ {
+		if v[i] < v[i-1] {
+			addError("sorted", field, errors, fmt.Sprintf("Must be in ascending order, but index %d was out of order", i))
+			return
+		}
+	}
+}
+
+// IsStrictlyIncreasing Checks that each entry of the slice is greater than the
+// one before, so it is both sorted and free of repeats.  The message gives the
+// first index that breaks the rule.
+func IsStrictlyIncreasing[T Ordered](
+	field string,
+	errors Errors,
+	v []T,
+) {
+	for i := 1; i < len(v); i++ {
+		if v[i] <= v[i-1] {
+			addError("strictly_increasing", field, errors, fmt.Sprintf("Must be strictly increasing, but index %d was not", i))
+			return
+		}
+	}
+}
+
+// IsTagList Checks a tag-input style list, recording "Too many tags" against
+// field when it has more than maxTags entries, and an error against the
+// PathIndex key, such as "tags[3]", for each tag longer than maxTagLen
+// characters, counted in runes.
+func IsTagList(
+	field string,
+	errors Errors,
+	v []string,
+	maxTags int,
+	maxTagLen int,
+) {
+	if len(v) > maxTags {
+		addError("tag_list", field, errors, "Too many tags")
+	}
+
+	for i, tag := range v {
+		IsStringLength(PathIndex(field, i), errors, tag, 0, maxTagLen)
+	}
+}
+
+// CountOccurrences returns the number of entries of v equal to target.
+func CountOccurrences[T comparable](v []T, target T) int {
+	count := 0
+	for _, e := range v {
+		if e == target {
+			count++
+		}
+	}
+	return count
+}
+
+// IsMaxOccurrences Checks that target appears in the slice no more than max
+// times, such as allowing at most 2 items to be marked "featured".
+func IsMaxOccurrences[T comparable](
+	field string,
+	errors Errors,
+	v []T,
+	target T,
+	max int,
+) {
+	if CountOccurrences(v, target) > max {
+		addError("max_occurrences", field, errors, fmt.Sprintf("%v may appear at most %d times", target, max))
+	}
+}
@@ -0,0 +1,46 @@
+package validate
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// IsValidGoTemplate Checks that the value parses as a text/template.  The
+// template is only parsed, never executed, so validation has no side effects.
+// Calls to any function beyond the builtins are syntax errors; use
+// IsValidGoTemplateFuncs to allow the functions the template will be executed
+// with.
+func IsValidGoTemplate(
+	field string,
+	errors Errors,
+	v string,
+) {
+	IsValidGoTemplateFuncs(field, errors, v)
+}
+
+// IsValidGoTemplateFuncs is like IsValidGoTemplate, but also allows calls to
+// the named functions.  It panics if a name isn't a valid Go identifier, such
+// as "to-upper", since that is a programming error rather than a problem with
+// the submitted value.
+func IsValidGoTemplateFuncs(
+	field string,
+	errors Errors,
+	v string,
+	funcs ...string,
+) {
+	fm := template.FuncMap{}
+	for _, name := range funcs {
+		fm[name] = func(...any) any { return nil }
+	}
+
+	if _, err := template.New("").Funcs(fm).Parse(v); err != nil {
+		// Parse errors read "template: <name>:<line>: <msg>", and the name is
+		// always empty here.
+		msg := err.Error()
+		if strings.HasPrefix(msg, "template: :") {
+			msg = "line " + strings.TrimPrefix(msg, "template: :")
+		}
+		addError("go_template", field, errors, fmt.Sprintf("Template syntax error: %s", msg))
+	}
+}
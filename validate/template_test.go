@@ -0,0 +1,41 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsValidGoTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		v     string
+		funcs []string
+		valid bool
+	}{
+		{"Hello {{.Name}}", nil, true},
+		{"{{if .OK}}yes{{end}}", nil, true},
+		{"{{upper .Name}}", []string{"upper"}, true},
+		{"{{upper .Name}}", nil, false},
+		{"{{if .OK}}", nil, false},
+		{"{{.Name", nil, false},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsValidGoTemplateFuncs("body", errs, tt.v, tt.funcs...)
+		if got := len(errs) == 0; got != tt.valid {
+			t.Errorf("IsValidGoTemplateFuncs(%q, %v) valid = %v, want %v: %v", tt.v, tt.funcs, got, tt.valid, errs)
+		}
+		if !tt.valid && !strings.HasPrefix(errs["body"][0], "Template syntax error: line ") {
+			t.Errorf("IsValidGoTemplateFuncs(%q) message = %q", tt.v, errs["body"][0])
+		}
+	}
+}
+
+func TestIsValidGoTemplateFuncsInvalidName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("IsValidGoTemplateFuncs with name \"to-upper\" did not panic")
+		}
+	}()
+	IsValidGoTemplateFuncs("body", Errors{}, "{{.Name}}", "to-upper")
+}
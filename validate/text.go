@@ -0,0 +1,358 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// HTMLTagRx matches tag-like sequences such as <b>, </p>, <!-- or <?php.  A
+// bare "<" that isn't followed by a tag name, as in "a < b", is not matched.
+var HTMLTagRx = regexp.MustCompile(`<[A-Za-z!/?][^<>]*>`)
+
+// URLLikeRx matches the URL-like substrings IsNoURL rejects: anything with an
+// http, https or ftp scheme, anything starting "www.", and bare domains
+// followed by a path, such as "spam.example.com/x".
+var URLLikeRx = regexp.MustCompile(`(?i)\b(?:(?:https?|ftp)://|www\.)\S|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}/`)
+
+// emojiRanges lists the rune ranges IsNoEmoji treats as emoji.
+var emojiRanges = [][2]rune{
+	{0x231A, 0x231B},   // Watch, hourglass (⌚, ⌛)
+	{0x23E9, 0x23FA},   // Media controls, alarm clock, stopwatch (⏩, ⏰)
+	{0x25FD, 0x25FE},   // Medium-small squares (◽, ◾)
+	{0x2600, 0x27BF},   // Miscellaneous Symbols, Dingbats
+	{0x2B00, 0x2BFF},   // Miscellaneous Symbols and Arrows (⭐, ⬛)
+	{0x20E3, 0x20E3},   // Combining enclosing keycap
+	{0xFE0F, 0xFE0F},   // Variation selector-16 (emoji presentation)
+	{0x1F000, 0x1FAFF}, // Pictographs, emoticons, transport, regional indicators, skin tones
+	{0xE0020, 0xE007F}, // Tags, used by subdivision flags
+}
+
+// IsNoHTML Checks that a string contains no HTML tag-like sequences.  This is
+// a validation aid for plain-text fields, not a sanitizer: it does not make a
+// value safe to render, so output must still be escaped.  Character entities
+// such as "&lt;script&gt;" are deliberately not rejected: they decode to plain
+// text rather than markup, and ordinary prose like "Tom &amp; Jerry" uses them.
+func IsNoHTML(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if HTMLTagRx.MatchString(v) {
+		addError("no_html", field, errors, "HTML is not allowed")
+	}
+}
+
+// StripHTML removes tag-like sequences matched by HTMLTagRx from s.  Like
+// IsNoHTML, it is not a sanitizer and the result must still be escaped.
+func StripHTML(s string) string {
+	return HTMLTagRx.ReplaceAllString(s, "")
+}
+
+// IsNoEmoji Checks that a string contains no emoji.  The ranges covered are
+// the emoji-presentation technical symbols (U+231A–U+231B and U+23E9–U+23FA),
+// the medium-small squares (U+25FD–U+25FE), Miscellaneous Symbols and
+// Dingbats (U+2600–U+27BF), Miscellaneous Symbols and Arrows
+// (U+2B00–U+2BFF), the keycap combiner (U+20E3), the emoji variation selector
+// (U+FE0F), the supplementary pictograph blocks (U+1F000–U+1FAFF, which
+// include regional indicators used for flags and skin-tone modifiers) and tag
+// characters (U+E0020–U+E007F).  ZWJ sequences are caught by their component
+// emoji rather than by the joiner itself, since U+200D is also used by
+// ordinary scripts.
+func IsNoEmoji(
+	field string,
+	errors Errors,
+	v string,
+) {
+	for _, r := range v {
+		for _, rng := range emojiRanges {
+			if r >= rng[0] && r <= rng[1] {
+				addError("no_emoji", field, errors, "Emoji are not allowed")
+				return
+			}
+		}
+	}
+}
+
+// IsNameChars Checks that a string contains only letters, spaces, hyphens and
+// apostrophes, as suits a legal name.  Combining marks are accepted so that
+// decomposed accented letters pass.
+func IsNameChars(
+	field string,
+	errors Errors,
+	v string,
+) {
+	for _, r := range v {
+		switch {
+		case unicode.IsLetter(r), unicode.IsMark(r):
+		case r == ' ', r == '-', r == '\'', r == '’':
+		default:
+			addError("name_chars", field, errors, "Must contain only letters, spaces, hyphens and apostrophes")
+			return
+		}
+	}
+}
+
+// IsMaxLines Checks that a string has no more than maxLines lines.  Lines are
+// separated by \n or \r\n, so a trailing line break starts a new, empty line.
+func IsMaxLines(
+	field string,
+	errors Errors,
+	v string,
+	maxLines int,
+) {
+	if len(splitLines(v)) > maxLines {
+		addError("max_lines", field, errors, fmt.Sprintf("Must not exceed %d lines", maxLines))
+	}
+}
+
+// IsMaxLineLength Checks that no line of a string is longer than maxLen
+// characters, counted in runes.  Only one error is recorded, however many
+// lines are too long.
+func IsMaxLineLength(
+	field string,
+	errors Errors,
+	v string,
+	maxLen int,
+) {
+	for _, line := range splitLines(v) {
+		if utf8.RuneCountInString(line) > maxLen {
+			addError("max_line_length", field, errors, fmt.Sprintf("A line exceeds the %d character limit", maxLen))
+			return
+		}
+	}
+}
+
+// splitLines splits s on \n, treating \r\n as a single line ending.
+func splitLines(s string) []string {
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
+
+// IsNotOnlyWhitespace Checks that a non-empty string contains something other
+// than whitespace or invisible formatting characters.  Unicode whitespace such
+// as the non-breaking space U+00A0 counts as whitespace, as do format
+// characters such as the zero-width space U+200B.  An empty string passes;
+// use IsNotEmpty to reject that too.
+func IsNotOnlyWhitespace(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if len(v) == 0 {
+		return
+	}
+
+	for _, r := range v {
+		if !unicode.IsSpace(r) && !unicode.Is(unicode.Cf, r) {
+			return
+		}
+	}
+
+	addError("not_only_whitespace", field, errors, "Must not be only whitespace")
+}
+
+// CollapseWhitespace trims s and replaces each internal run of Unicode
+// whitespace with a single space, for normalizing values before storage.
+func CollapseWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// IsWordCountBetween Checks that a string has between m and n words
+// (inclusive), where words are runs of non-whitespace as split by
+// strings.Fields.  Punctuation attached to a word is part of it, so "hello,
+// world" is two words, while a standalone "-" counts as a word of its own.
+func IsWordCountBetween(
+	field string,
+	errors Errors,
+	v string,
+	m int,
+	n int,
+) {
+	between("word_count", field, errors, len(strings.Fields(v)), m, n, fmt.Sprintf("Must contain between %d and %d words", m, n))
+}
+
+// ContainsAtLeastOneOf Checks that a string contains at least one rune from
+// charset, recording the message otherwise.  For example, charset
+// "0123456789" requires at least one digit.
+func ContainsAtLeastOneOf(
+	field string,
+	errors Errors,
+	v string,
+	charset string,
+	message string,
+) {
+	if !strings.ContainsAny(v, charset) {
+		addError("contains_one_of", field, errors, message)
+	}
+}
+
+// IsGraphemeLength Checks that a string has either exactly m == n, or between
+// m and n (inclusive), user-perceived characters.  This is the most "human"
+// length measure: "👨‍👩‍👧" and "é" written with a combining accent each count
+// as one, where IsStringLength counts runes and IsByteLength counts bytes.
+// Segmentation is a minimal form of the Unicode rules, and handles combining
+// marks, emoji modifiers and ZWJ sequences, regional-indicator flag pairs, tag
+// sequences and CR LF.  Hangul syllables built from separate jamo are counted
+// per jamo.
+func IsGraphemeLength(
+	field string,
+	errors Errors,
+	v string,
+	m int,
+	n int,
+) {
+	between("grapheme_length", field, errors, GraphemeCount(v), m, n, stringLengthMessage(m, n))
+}
+
+// GraphemeCount returns the number of grapheme clusters in s, segmented as
+// described for IsGraphemeLength.
+func GraphemeCount(s string) int {
+	count := 0
+	var prev rune
+	// riOpen is set while the current cluster is a lone regional indicator,
+	// waiting for the second half of a flag.
+	riOpen := false
+
+	for i, r := range s {
+		switch {
+		case i > 0 && prev == '\r' && r == '\n':
+		case i > 0 && prev == 0x200D:
+		case i > 0 && isGraphemeExtend(r):
+		case riOpen && isRegionalIndicator(r):
+			riOpen = false
+		default:
+			count++
+			riOpen = isRegionalIndicator(r)
+		}
+		prev = r
+	}
+
+	return count
+}
+
+// isGraphemeExtend reports whether r continues the cluster before it rather
+// than starting a new one.
+func isGraphemeExtend(r rune) bool {
+	switch {
+	case unicode.IsMark(r):
+		return true
+	case r == 0x200D: // Zero width joiner
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // Emoji skin-tone modifiers
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // Tags
+		return true
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// DefaultDelimiters are the pairs IsBalanced checks when none are given.
+var DefaultDelimiters = [][2]rune{{'(', ')'}, {'[', ']'}, {'{', '}'}}
+
+// IsBalanced Checks that each pair of opening and closing delimiters in a
+// string is balanced and properly nested, using DefaultDelimiters when no
+// pairs are given.  The message gives the 1-based character position of the
+// first problem: a closer that doesn't match the innermost open delimiter, or
+// failing that, the earliest delimiter left unclosed.  A pair whose opener and
+// closer are the same, such as quotes, alternates between opening and closing.
+func IsBalanced(
+	field string,
+	errors Errors,
+	v string,
+	pairs ...[2]rune,
+) {
+	if len(pairs) == 0 {
+		pairs = DefaultDelimiters
+	}
+
+	type open struct {
+		closer rune
+		pos    int
+	}
+	var stack []open
+
+	pos := 0
+	for _, r := range v {
+		pos++
+
+		if len(stack) > 0 && stack[len(stack)-1].closer == r {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		for _, p := range pairs {
+			if r == p[0] {
+				stack = append(stack, open{closer: p[1], pos: pos})
+				break
+			}
+			if r == p[1] {
+				addError("balanced", field, errors, fmt.Sprintf("Unbalanced delimiters at position %d", pos))
+				return
+			}
+		}
+	}
+
+	if len(stack) > 0 {
+		addError("balanced", field, errors, fmt.Sprintf("Unbalanced delimiters at position %d", stack[0].pos))
+	}
+}
+
+// IsNoURL Checks that a string contains no links, as a spam-mitigation aid for
+// free-text fields.  Detection is deliberately conservative, matching only
+// what URLLikeRx describes, so ordinary prose such as "e.g." or "v1.2.3" is
+// not flagged.  The cost is that a bare domain with no path, such as
+// "example.com", is not caught either.
+func IsNoURL(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if URLLikeRx.MatchString(v) {
+		addError("no_url", field, errors, "Links are not allowed")
+	}
+}
+
+// IsHandle Checks that the value is a social handle such as "@jane_doe".  A
+// single leading "@" is optional and is not counted, so "@jane" and "jane"
+// are both accepted; store the handle without it.  The rest must be 1 to
+// maxLen letters, digits or underscores, and not all digits, so a handle
+// can't be mistaken for a numeric ID.
+func IsHandle(
+	field string,
+	errors Errors,
+	v string,
+	maxLen int,
+) {
+	h := strings.TrimPrefix(v, "@")
+	if len(h) == 0 || len(h) > maxLen || isDigits(h) {
+		addError("handle", field, errors, "Must be a valid handle")
+		return
+	}
+
+	for i := 0; i < len(h); i++ {
+		c := h[i]
+		if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_') {
+			addError("handle", field, errors, "Must be a valid handle")
+			return
+		}
+	}
+}
+
+// IsOneLine Checks that a string contains no carriage return or line feed.
+// Besides suiting single-line fields, this guards against header injection
+// when the value is written into email or HTTP headers.
+func IsOneLine(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if strings.ContainsAny(v, "\r\n") {
+		addError("one_line", field, errors, "Must be a single line")
+	}
+}
@@ -0,0 +1,167 @@
+package validate
+
+import "testing"
+
+func TestIsNoHTML(t *testing.T) {
+	tests := []struct {
+		v     string
+		valid bool
+	}{
+		{"<script>", false},
+		{"<script>alert(1)</script>", false},
+		{`<a href="x">link</a>`, false},
+		{"<!-- comment -->", false},
+		{"</p>", false},
+		{"a < b", true},
+		{"a < b > c", true},
+		{"1 <2", true},
+		{"Hello, world!", true},
+		{"&lt;script&gt;", true},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsNoHTML("bio", errs, tt.v)
+		if got := len(errs) == 0; got != tt.valid {
+			t.Errorf("IsNoHTML(%q) valid = %v, want %v", tt.v, got, tt.valid)
+		}
+	}
+}
+
+func TestStripHTML(t *testing.T) {
+	if got := StripHTML("<b>bold</b> and a < b"); got != "bold and a < b" {
+		t.Errorf("StripHTML = %q", got)
+	}
+}
+
+func TestIsNoEmoji(t *testing.T) {
+	rejected := map[string]string{
+		"flag":         "Team 🇦🇺",
+		"emoticon":     "Bob 😀",
+		"zwj sequence": "👨‍👩‍👧",
+		"skin tone":    "👍🏽",
+		"keycap":       "1️⃣",
+		"watch":        "⌚",
+		"alarm clock":  "⏰",
+		"square":       "◾",
+		"dingbat":      "✅",
+		"star":         "⭐",
+	}
+	for name, v := range rejected {
+		errs := Errors{}
+		IsNoEmoji("name", errs, v)
+		if len(errs["name"]) != 1 {
+			t.Errorf("%s: IsNoEmoji(%q) = %v, want an error", name, v, errs)
+		}
+	}
+
+	for _, v := range []string{"O'Brien", "José Müller", "李小龍", "Anne-Marie"} {
+		errs := Errors{}
+		IsNoEmoji("name", errs, v)
+		if len(errs) != 0 {
+			t.Errorf("IsNoEmoji(%q) = %v, want no errors", v, errs)
+		}
+	}
+}
+
+func TestIsNameChars(t *testing.T) {
+	tests := []struct {
+		v     string
+		valid bool
+	}{
+		{"O'Brien", true},
+		{"Anne-Marie O’Neil", true},
+		{"José", true},
+		{"Jose\u0301", true},
+		{"Team 🇦🇺", false},
+		{"Bob1", false},
+		{"Bob!", false},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsNameChars("name", errs, tt.v)
+		if got := len(errs) == 0; got != tt.valid {
+			t.Errorf("IsNameChars(%q) valid = %v, want %v", tt.v, got, tt.valid)
+		}
+	}
+}
+
+func TestIsNotOnlyWhitespace(t *testing.T) {
+	// Non-breaking spaces, an ideographic space and a zero-width space are
+	// all blank.
+	blank := []string{" ", "\t\n", "\u00a0", "\u00a0 \u00a0", " \u3000", "\u200b"}
+	for _, v := range blank {
+		errs := Errors{}
+		IsNotOnlyWhitespace("q", errs, v)
+		if len(errs["q"]) != 1 {
+			t.Errorf("IsNotOnlyWhitespace(%q) = %v, want an error", v, errs)
+		}
+	}
+
+	for _, v := range []string{"", "a", " a "} {
+		errs := Errors{}
+		IsNotOnlyWhitespace("q", errs, v)
+		if len(errs) != 0 {
+			t.Errorf("IsNotOnlyWhitespace(%q) = %v, want no errors", v, errs)
+		}
+	}
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	got := CollapseWhitespace("  hello \t\u00a0 big\n\nworld  ")
+	if got != "hello big world" {
+		t.Errorf("CollapseWhitespace = %q", got)
+	}
+}
+
+func TestIsNoURL(t *testing.T) {
+	links := []string{
+		"visit http://spam.co",
+		"spam.example.com/x",
+		"HTTPS://EXAMPLE.COM",
+		"go to www.spam.com now",
+		"ftp://files.example.org",
+	}
+	prose := []string{
+		"e.g. this and/or that",
+		"version 1.2.3 is out",
+		"I work at example.com",
+		"see page 3.30/4",
+		"Mr. Smith went home.",
+	}
+
+	for _, v := range links {
+		errs := Errors{}
+		IsNoURL("bio", errs, v)
+		if len(errs["bio"]) != 1 || errs["bio"][0] != "Links are not allowed" {
+			t.Errorf("IsNoURL(%q) = %v, want a link error", v, errs)
+		}
+	}
+	for _, v := range prose {
+		errs := Errors{}
+		IsNoURL("bio", errs, v)
+		if len(errs) != 0 {
+			t.Errorf("IsNoURL(%q) = %v, want no errors", v, errs)
+		}
+	}
+}
+
+func TestIsOneLine(t *testing.T) {
+	tests := map[string]bool{
+		"Just a subject":                         true,
+		"":                                       true,
+		"line1\nline2":                           false,
+		"line1\r\nline2":                         false,
+		"Subject\rBcc: victim@example.com":       false,
+		"Subject\r\nBcc: victim@example.com\r\n": false,
+	}
+
+	for v, valid := range tests {
+		errs := Errors{}
+		IsOneLine("subject", errs, v)
+		if got := len(errs) == 0; got != valid {
+			t.Errorf("IsOneLine(%q) valid = %v, want %v", v, got, valid)
+		}
+	}
+}
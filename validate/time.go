@@ -0,0 +1,286 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Now returns the current time for validators that compare against it.  It
+// may be replaced, for example in tests, to make those validators
+// deterministic.
+var Now = time.Now
+
+// IsWeekday Checks that the date falls on Monday to Friday, in its own
+// location.
+func IsWeekday(
+	field string,
+	errors Errors,
+	v time.Time,
+) {
+	if isWeekend(v) {
+		addError("weekday", field, errors, "Must be a weekday")
+	}
+}
+
+// IsBusinessDay Checks that the date is a weekday and not one of the given
+// holidays.  Holidays are matched by calendar date, each read in its own
+// location, so the time of day on either side is ignored.
+func IsBusinessDay(
+	field string,
+	errors Errors,
+	v time.Time,
+	holidays ...time.Time,
+) {
+	if isWeekend(v) {
+		addError("business_day", field, errors, "Must be a business day")
+		return
+	}
+
+	for _, h := range holidays {
+		if sameDate(v, h) {
+			addError("business_day", field, errors, "Must be a business day")
+			return
+		}
+	}
+}
+
+func isWeekend(v time.Time) bool {
+	d := v.Weekday()
+	return d == time.Saturday || d == time.Sunday
+}
+
+// sameDate reports whether a and b fall on the same calendar date, each read
+// in its own location.
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+// IsWithinBusinessHours Checks that the time of day of v, read in v's own
+// location, falls within the window from open (inclusive) to close
+// (exclusive), both given as durations since midnight.  If close is before
+// open, the window is treated as running overnight, so 22h to 6h accepts both
+// 23:00 and 05:00.
+func IsWithinBusinessHours(
+	field string,
+	errors Errors,
+	v time.Time,
+	open time.Duration,
+	close time.Duration,
+) {
+	t := timeOfDay(v)
+
+	var within bool
+	if close < open {
+		within = t >= open || t < close
+	} else {
+		within = t >= open && t < close
+	}
+
+	if !within {
+		addError("business_hours", field, errors, "Must be within business hours")
+	}
+}
+
+// timeOfDay returns the wall clock time of v as a duration since midnight.
+// It is read from the clock rather than subtracting midnight, so days with a
+// daylight saving transition don't skew the result.
+func timeOfDay(v time.Time) time.Duration {
+	h, m, s := v.Clock()
+	return time.Duration(h)*time.Hour +
+		time.Duration(m)*time.Minute +
+		time.Duration(s)*time.Second +
+		time.Duration(v.Nanosecond())
+}
+
+// MaxUnixTimestamp is the latest Unix timestamp, in seconds, accepted by
+// IsUnixTimestamp: the last second of the year 9999 UTC.
+const MaxUnixTimestamp int64 = 253402300799
+
+// IsUnixTimestamp Checks that an integer is a plausible Unix timestamp in
+// seconds, being neither negative nor later than MaxUnixTimestamp.
+func IsUnixTimestamp(
+	field string,
+	errors Errors,
+	v int64,
+) {
+	if v < 0 || v > MaxUnixTimestamp {
+		addError("unix_timestamp", field, errors, "Must be a valid timestamp")
+	}
+}
+
+// IsPastTimestamp Checks that a Unix timestamp in seconds is not later than
+// Now.
+func IsPastTimestamp(
+	field string,
+	errors Errors,
+	v int64,
+) {
+	if v > Now().Unix() {
+		addError("past_timestamp", field, errors, "Timestamp must not be in the future")
+	}
+}
+
+// IsFutureTimestamp Checks that a Unix timestamp in seconds is later than Now.
+func IsFutureTimestamp(
+	field string,
+	errors Errors,
+	v int64,
+) {
+	if v <= Now().Unix() {
+		addError("future_timestamp", field, errors, "Timestamp must be in the future")
+	}
+}
+
+// IsDateOrderedBefore Checks that start is strictly before end, recording the
+// message otherwise.  The error goes against whichever field is passed, so it
+// can be shown beside either the start or the end input.
+func IsDateOrderedBefore(
+	field string,
+	errors Errors,
+	start time.Time,
+	end time.Time,
+	message string,
+) {
+	if !start.Before(end) {
+		addError("date_order", field, errors, message)
+	}
+}
+
+// IsDateOrderedBeforeOrEqual is like IsDateOrderedBefore, but also accepts
+// start and end being the same instant.
+func IsDateOrderedBeforeOrEqual(
+	field string,
+	errors Errors,
+	start time.Time,
+	end time.Time,
+	message string,
+) {
+	if start.After(end) {
+		addError("date_order", field, errors, message)
+	}
+}
+
+var iso8601DateTimeRx = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+// IsISO8601DateTime Checks that the value is a complete RFC 3339 datetime with
+// a timezone, such as "2023-06-01T12:00:00Z" or "2023-06-01T12:00:00+02:00".
+// Forms that time.Parse would otherwise tolerate, such as a space in place of
+// the "T", a missing offset or out of range components like "24:00:00", are
+// rejected.
+func IsISO8601DateTime(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if !iso8601DateTimeRx.MatchString(v) {
+		addError("iso8601_datetime", field, errors, "Must be an ISO 8601 datetime")
+		return
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil || t.Format("2006-01-02T15:04:05") != v[:19] {
+		addError("iso8601_datetime", field, errors, "Must be an ISO 8601 datetime")
+	}
+}
+
+// IsScheduledWithin Checks that v is at least minAhead and at most maxAhead
+// after Now, such as a booking between 1 hour and 90 days out.
+func IsScheduledWithin(
+	field string,
+	errors Errors,
+	v time.Time,
+	minAhead time.Duration,
+	maxAhead time.Duration,
+) {
+	now := Now()
+	if v.Before(now.Add(minAhead)) {
+		addError("scheduled_within", field, errors, fmt.Sprintf("Must be at least %s from now", humanDuration(minAhead)))
+	} else if v.After(now.Add(maxAhead)) {
+		addError("scheduled_within", field, errors, fmt.Sprintf("Cannot be more than %s in the future", humanDuration(maxAhead)))
+	}
+}
+
+// humanDuration formats d for messages in the largest whole unit of days,
+// hours or minutes that fits exactly, falling back to d.String().
+func humanDuration(d time.Duration) string {
+	plural := func(n time.Duration, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	switch {
+	case d == 0:
+		return "0 minutes"
+	case d%(24*time.Hour) == 0:
+		return plural(d/(24*time.Hour), "day")
+	case d%time.Hour == 0:
+		return plural(d/time.Hour, "hour")
+	case d%time.Minute == 0:
+		return plural(d/time.Minute, "minute")
+	}
+	return d.String()
+}
+
+// IsDateBetween Checks that v is no earlier than min and no later than max,
+// comparing instants, so the time of day counts.  The bounds are formatted in
+// the message with layout, so they match the form's display format, as in
+// "Must be between 2023-01-01 and 2023-12-31".
+func IsDateBetween(
+	field string,
+	errors Errors,
+	v time.Time,
+	min time.Time,
+	max time.Time,
+	layout string,
+) {
+	if v.Before(min) || v.After(max) {
+		addError("date_between", field, errors, fmt.Sprintf("Must be between %s and %s", min.Format(layout), max.Format(layout)))
+	}
+}
+
+// IsDurationRangeValid Checks a time-of-day window given as durations since
+// midnight, such as 9h to 17h: both must be within [0, 24h) and start must be
+// before end.
+func IsDurationRangeValid(
+	field string,
+	errors Errors,
+	start time.Duration,
+	end time.Duration,
+) {
+	isDurationRangeValid(field, errors, start, end, false)
+}
+
+// IsDurationRangeValidWrap is like IsDurationRangeValid, but allows end to be
+// before start for a window running overnight, such as 22h to 6h, matching
+// IsWithinBusinessHours.  start and end still may not be equal.
+func IsDurationRangeValidWrap(
+	field string,
+	errors Errors,
+	start time.Duration,
+	end time.Duration,
+) {
+	isDurationRangeValid(field, errors, start, end, true)
+}
+
+func isDurationRangeValid(
+	field string,
+	errors Errors,
+	start time.Duration,
+	end time.Duration,
+	allowWrap bool,
+) {
+	day := 24 * time.Hour
+	if start < 0 || start >= day || end < 0 || end >= day {
+		addError("duration_range", field, errors, "Must be a time of day between 00:00 and 23:59")
+		return
+	}
+
+	if start == end || (!allowWrap && start > end) {
+		addError("duration_range", field, errors, "Start must be before end")
+	}
+}
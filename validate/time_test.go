@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+// fixNow sets Now to testNow for the rest of the test.
+func fixNow(t *testing.T) {
+	Now = func() time.Time { return testNow }
+	t.Cleanup(func() { Now = time.Now })
+}
+
+func TestIsPastAndFutureTimestamp(t *testing.T) {
+	fixNow(t)
+	now := testNow.Unix()
+
+	tests := []struct {
+		v      int64
+		past   bool
+		future bool
+	}{
+		{now - 1, true, false},
+		{now, true, false},
+		{now + 1, false, true},
+		{0, true, false},
+	}
+
+	for _, tt := range tests {
+		past, future := Errors{}, Errors{}
+		IsPastTimestamp("at", past, tt.v)
+		IsFutureTimestamp("at", future, tt.v)
+
+		if got := len(past) == 0; got != tt.past {
+			t.Errorf("IsPastTimestamp(%d) valid = %v, want %v", tt.v, got, tt.past)
+		}
+		if got := len(future) == 0; got != tt.future {
+			t.Errorf("IsFutureTimestamp(%d) valid = %v, want %v", tt.v, got, tt.future)
+		}
+	}
+}
+
+func TestIsScheduledWithin(t *testing.T) {
+	fixNow(t)
+
+	tests := []struct {
+		v    time.Time
+		want []string
+	}{
+		{testNow.Add(time.Hour), nil},
+		{testNow.Add(90 * 24 * time.Hour), nil},
+		{testNow.Add(2 * time.Hour), nil},
+		{testNow.Add(59 * time.Minute), []string{"Must be at least 1 hour from now"}},
+		{testNow.Add(-time.Hour), []string{"Must be at least 1 hour from now"}},
+		{testNow.Add(90*24*time.Hour + time.Second), []string{"Cannot be more than 90 days in the future"}},
+	}
+
+	for _, tt := range tests {
+		errs := Errors{}
+		IsScheduledWithin("booking", errs, tt.v, time.Hour, 90*24*time.Hour)
+		if got := errs["booking"]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("IsScheduledWithin(now%+v) = %v, want %v", tt.v.Sub(testNow), got, tt.want)
+		}
+	}
+}
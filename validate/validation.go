@@ -2,7 +2,11 @@ package validate
 
 import (
 	"fmt"
+	"math"
+	"net/url"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 )
 
 type Errors map[string][]string
@@ -13,6 +17,10 @@ func (e Errors) Add(field string, msg string) {
 
 var EmailRx = regexp.MustCompile(`^\S+@\S+$`)
 
+var PhoneE164Rx = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+var UUIDRx = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
 type Lengthable[Q any, U comparable] interface {
 	[]Q | map[U]Q
 }
@@ -21,42 +29,190 @@ type NumericComparable interface {
 	int | int8 | int16 | int32 | int64 | uint | uint8 | uint16 | uint32 | uint64
 }
 
+// Ordered is any type supporting the < and > operators.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// ErrorFormatter, when non-nil, transforms every message before it is stored.
+// It runs on every AddError call, including those made by the built-in
+// validators, and receives the field the message is recorded against.
+var ErrorFormatter func(field, msg string) string
+
+// OnError, when non-nil, is called whenever a built-in validator records an
+// error, such as to count failures in metrics.  rule is a stable name for the
+// validator, such as "email" or "string_length", and msg is the message as
+// stored, after ErrorFormatter.  Errors added directly with AddError are not
+// reported.
+//
+// Warnings recorded with Result.AddWarning or the WarnX helpers, such as
+// WarnStringMinLength, are not reported either, so OnError only ever sees
+// blocking failures.
+var OnError func(field, rule, msg string)
+
 // Validate records the provided error, if not nil, inside the errors list
 // marked against the provided field.
 func AddError(field string, errors Errors, msg string) {
+	if ErrorFormatter != nil {
+		msg = ErrorFormatter(field, msg)
+	}
 	errors[field] = append(errors[field], msg)
 }
 
+// addError records msg like AddError, then reports it to OnError tagged with
+// the rule name of the built-in validator that failed.
+func addError(rule string, field string, errors Errors, msg string) {
+	AddError(field, errors, msg)
+	if OnError != nil {
+		msgs := errors[field]
+		OnError(field, rule, msgs[len(msgs)-1])
+	}
+}
+
 // StringLength Checks that a string has either an exact count of characters,
-// or fits within the specified range of m to n (inclusive).
+// or fits within the specified range of m to n (inclusive).  Characters are
+// counted as runes, so "é" is one character even though it is two bytes.  Use
+// IsByteLength where the limit is on storage size instead.
+//
+// For one-sided limits, pass m as 0 or n as math.MaxInt and the message reads
+// "Must be at most n" or "Must be at least m" accordingly.
 func IsStringLength(
 	field string,
 	errors Errors,
 	v string,
 	m int,
 	n int,
+) {
+	between("string_length", field, errors, utf8.RuneCountInString(v), m, n, stringLengthMessage(m, n))
+}
+
+// stringLengthMessage returns the message for a character count outside of m
+// to n, worded for one-sided limits where m is 0 or n is math.MaxInt.
+func stringLengthMessage(m int, n int) string {
+	switch {
+	case m == n:
+		return fmt.Sprintf("Must be exactly %d characters long", m)
+	case m == 0:
+		return fmt.Sprintf("Must be at most %d characters long", n)
+	case n == math.MaxInt:
+		return fmt.Sprintf("Must be at least %d characters long", m)
+	default:
+		return fmt.Sprintf("Must be between %d and %d characters long", m, n)
+	}
+}
+
+// IsStringMinLength Checks that a string is at least the listed size, counted
+// in runes.
+func IsStringMinLength(
+	field string,
+	errors Errors,
+	v string,
+	m int,
+) {
+	if utf8.RuneCountInString(v) < m {
+		addError("string_min_length", field, errors, fmt.Sprintf("Must be at least %d characters long", m))
+	}
+}
+
+// WarnStringLength records the message IsStringLength would, but as an
+// advisory warning, such as into Result.Warnings.  Unlike IsStringLength it
+// is not reported to OnError.
+func WarnStringLength(
+	field string,
+	warnings Errors,
+	v string,
+	m int,
+	n int,
+) {
+	if c := utf8.RuneCountInString(v); c < m || c > n {
+		AddError(field, warnings, stringLengthMessage(m, n))
+	}
+}
+
+// WarnStringMinLength records the message IsStringMinLength would, but as an
+// advisory warning, such as into Result.Warnings.  Unlike IsStringMinLength
+// it is not reported to OnError.
+func WarnStringMinLength(
+	field string,
+	warnings Errors,
+	v string,
+	m int,
+) {
+	if utf8.RuneCountInString(v) < m {
+		AddError(field, warnings, fmt.Sprintf("Must be at least %d characters long", m))
+	}
+}
+
+// IsByteLength Checks that a string's encoded size is either exactly m == n
+// bytes, or between m and n bytes (inclusive).  This differs from
+// IsStringLength for any non-ASCII text, and is the check to use for limits
+// imposed by storage, such as a database column size.
+func IsByteLength(
+	field string,
+	errors Errors,
+	v string,
+	m int,
+	n int,
 ) {
 	var msg string
 	if m == n {
-		msg = fmt.Sprintf("Must be exactly %d characters long", m)
+		msg = fmt.Sprintf("Must be exactly %d bytes long", m)
 	} else {
-		msg = fmt.Sprintf("Must be between %d and %d characters long", m, n)
+		msg = fmt.Sprintf("Must be between %d and %d bytes long", m, n)
 	}
 
-	if len(v) < m || len(v) > n {
-		AddError(field, errors, msg)
+	between("byte_length", field, errors, len(v), m, n, msg)
+}
+
+// IsTotalByteLength Checks that the combined size of all parts, in bytes, is
+// no more than max, for limits on a whole document that no single field check
+// can express.  The error is recorded against field, typically a form-level
+// key.
+func IsTotalByteLength(
+	field string,
+	errors Errors,
+	max int,
+	parts ...string,
+) {
+	total := 0
+	for _, p := range parts {
+		total += len(p)
+	}
+
+	if total > max {
+		addError("total_byte_length", field, errors, fmt.Sprintf("Combined content exceeds %d bytes", max))
 	}
 }
 
-// IsStringMinLength Checks that a string is at least the listed size.
-func IsStringMinLength(
+// Between Checks that v lies between m and n (inclusive) of any ordered type,
+// recording the message otherwise.  Strings are compared lexically, byte by
+// byte.  It is the bounds check shared by the typed range validators.
+func Between[T Ordered](
 	field string,
 	errors Errors,
-	v string,
-	m int,
+	v T,
+	m T,
+	n T,
+	message string,
 ) {
-	if len(v) < m {
-		AddError(field, errors, fmt.Sprintf("Must be at least %d characters long", m))
+	between("between", field, errors, v, m, n, message)
+}
+
+// between is Between, recording any error under the calling validator's rule.
+func between[T Ordered](
+	rule string,
+	field string,
+	errors Errors,
+	v T,
+	m T,
+	n T,
+	message string,
+) {
+	if v < m || v > n {
+		addError(rule, field, errors, message)
 	}
 }
 
@@ -77,9 +233,7 @@ func IsNumberBetween[T NumericComparable](
 		msg = fmt.Sprintf("Must be between %d and %d, but was %d", m, n, v)
 	}
 
-	if v < m || v > n {
-		AddError(field, errors, msg)
-	}
+	between("number_between", field, errors, v, m, n, msg)
 }
 
 func IsNotEmpty(
@@ -88,10 +242,36 @@ func IsNotEmpty(
   v string,
 ) {
   if len(v) == 0 {
-    AddError(field, errors, "Must not be empty")
+    addError("not_empty", field, errors, "Must not be empty")
   }
 }
 
+// IsAccepted Checks that a terms-style checkbox has been ticked.
+func IsAccepted(
+	field string,
+	errors Errors,
+	v bool,
+) {
+	if !v {
+		addError("accepted", field, errors, "You must accept the terms to continue")
+	}
+}
+
+// IsAcceptedString is IsAccepted for a raw form value, treating "on", "true",
+// "1" and "yes", in any case, as accepted.  An unticked HTML checkbox isn't
+// submitted at all, so a missing value arrives as "" and is not accepted.
+func IsAcceptedString(
+	field string,
+	errors Errors,
+	v string,
+) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "on", "true", "1", "yes":
+	default:
+		addError("accepted", field, errors, "You must accept the terms to continue")
+	}
+}
+
 // Size checks that an array or map has either exactly m == n entries, or
 // between m and n entries (inclusive)
 func IsSize[T Lengthable[Q, U], Q any, U comparable](
@@ -108,9 +288,7 @@ func IsSize[T Lengthable[Q, U], Q any, U comparable](
 		msg = fmt.Sprintf("Must have between %d and %d entries, but had %d", m, n, len(v))
 	}
 
-	if len(v) < m || len(v) > n {
-		AddError(field, errors, msg)
-	}
+	between("size", field, errors, len(v), m, n, msg)
 }
 
 // MinSize checks that an array or map has at least n entries
@@ -129,7 +307,7 @@ func IsMinSize[T Lengthable[Q, U], Q any, U comparable](
 	msg = fmt.Sprintf("Must have a minimum of %d %s, but had %d", n, entry, len(v))
 
 	if len(v) < n {
-		AddError(field, errors, msg)
+		addError("min_size", field, errors, msg)
 	}
 }
 
@@ -140,9 +318,34 @@ func IsRegex(
 	v string,
 	rx *regexp.Regexp,
 	message string,
+) {
+	isRegex("regex", field, errors, v, rx, message)
+}
+
+// isRegex is IsRegex, recording any error under the calling validator's rule.
+func isRegex(
+	rule string,
+	field string,
+	errors Errors,
+	v string,
+	rx *regexp.Regexp,
+	message string,
 ) {
 	if !rx.MatchString(v) {
-		AddError(field, errors, message)
+		addError(rule, field, errors, message)
+	}
+}
+
+// IsValidRegex Confirms that value is itself a regular expression that
+// compiles, surfacing the compile error in the message.  Use it before storing
+// a user-supplied pattern that will later be passed to IsRegex.
+func IsValidRegex(
+	field string,
+	errors Errors,
+	v string,
+) {
+	if _, err := regexp.Compile(v); err != nil {
+		addError("valid_regex", field, errors, fmt.Sprintf("Must be a valid regular expression: %s", err))
 	}
 }
 
@@ -153,5 +356,37 @@ func IsEmail(
 	errors Errors,
 	v string,
 ) {
-	IsRegex(field, errors, v, EmailRx, "Email address is invalid")
+	isRegex("email", field, errors, v, EmailRx, "Email address is invalid")
+}
+
+// IsPhoneE164 Confirms that value is a phone number in E.164 format, such as
+// "+14155552671": a "+", then up to 15 digits not starting with 0.
+func IsPhoneE164(
+	field string,
+	errors Errors,
+	v string,
+) {
+	isRegex("phone_e164", field, errors, v, PhoneE164Rx, "Must be a valid E.164 phone number")
+}
+
+// IsUUID Confirms that value is a UUID in its canonical hyphenated form, in
+// either case.
+func IsUUID(
+	field string,
+	errors Errors,
+	v string,
+) {
+	isRegex("uuid", field, errors, v, UUIDRx, "Must be a valid UUID")
+}
+
+// IsURL Confirms that value is an absolute http or https URL with a host.
+func IsURL(
+	field string,
+	errors Errors,
+	v string,
+) {
+	u, err := url.Parse(v)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		addError("url", field, errors, "Must be a valid URL")
+	}
 }
@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// assertErrors fails unless field holds exactly want, where a nil want means
+// the field must have no errors.
+func assertErrors(t *testing.T, errs Errors, field string, want ...string) {
+	t.Helper()
+	if got := errs[field]; !reflect.DeepEqual(got, []string(want)) {
+		t.Errorf("errors[%q] = %q, want %q", field, got, want)
+	}
+}
+
+func TestIsStringLengthBounds(t *testing.T) {
+	msg := "Must be between 3 and 5 characters long"
+	tests := []struct {
+		v    string
+		want []string
+	}{
+		{"ab", []string{msg}},
+		{"abc", nil},
+		{"abcde", nil},
+		{"abcdef", []string{msg}},
+		// Counted in runes, not bytes.
+		{"ééé", nil},
+		{"éééééé", []string{msg}},
+	}
+	for _, tt := range tests {
+		errs := Errors{}
+		IsStringLength("f", errs, tt.v, 3, 5)
+		assertErrors(t, errs, "f", tt.want...)
+	}
+
+	exact := "Must be exactly 4 characters long"
+	for v, want := range map[string][]string{"abc": {exact}, "abcd": nil, "abcde": {exact}} {
+		errs := Errors{}
+		IsStringLength("f", errs, v, 4, 4)
+		assertErrors(t, errs, "f", want...)
+	}
+}
+
+func TestIsByteLengthBounds(t *testing.T) {
+	msg := "Must be between 3 and 5 bytes long"
+	tests := []struct {
+		v    string
+		want []string
+	}{
+		{"ab", []string{msg}},
+		{"abc", nil},
+		{"abcde", nil},
+		{"abcdef", []string{msg}},
+		// "é" is two bytes.
+		{"ééé", []string{msg}},
+	}
+	for _, tt := range tests {
+		errs := Errors{}
+		IsByteLength("f", errs, tt.v, 3, 5)
+		assertErrors(t, errs, "f", tt.want...)
+	}
+
+	exact := "Must be exactly 4 bytes long"
+	for v, want := range map[string][]string{"abc": {exact}, "abcd": nil, "abcde": {exact}} {
+		errs := Errors{}
+		IsByteLength("f", errs, v, 4, 4)
+		assertErrors(t, errs, "f", want...)
+	}
+}
+
+func TestIsNumberBetweenBounds(t *testing.T) {
+	tests := []struct {
+		v    int
+		want []string
+	}{
+		{9, []string{"Must be between 10 and 20, but was 9"}},
+		{10, nil},
+		{20, nil},
+		{21, []string{"Must be between 10 and 20, but was 21"}},
+	}
+	for _, tt := range tests {
+		errs := Errors{}
+		IsNumberBetween("f", errs, tt.v, 10, 20)
+		assertErrors(t, errs, "f", tt.want...)
+	}
+
+	for v, want := range map[uint8][]string{
+		6: {"Must be exactly 7, but was 6"},
+		7: nil,
+		8: {"Must be exactly 7, but was 8"},
+	} {
+		errs := Errors{}
+		IsNumberBetween("f", errs, v, 7, 7)
+		assertErrors(t, errs, "f", want...)
+	}
+}
+
+func TestIsSizeBounds(t *testing.T) {
+	tests := []struct {
+		v    []int
+		want []string
+	}{
+		{[]int{1}, []string{"Must have between 2 and 3 entries, but had 1"}},
+		{[]int{1, 2}, nil},
+		{[]int{1, 2, 3}, nil},
+		{[]int{1, 2, 3, 4}, []string{"Must have between 2 and 3 entries, but had 4"}},
+	}
+	for _, tt := range tests {
+		errs := Errors{}
+		IsSize[[]int, int, int]("f", errs, tt.v, 2, 3)
+		assertErrors(t, errs, "f", tt.want...)
+	}
+
+	m := map[string]int{"a": 1, "b": 2}
+	for n, want := range map[int][]string{
+		1: {"Must have exactly 1 entries, but had 2"},
+		2: nil,
+		3: {"Must have exactly 3 entries, but had 2"},
+	} {
+		errs := Errors{}
+		IsSize[map[string]int, int, string]("f", errs, m, n, n)
+		assertErrors(t, errs, "f", want...)
+	}
+}
+
+func TestBetween(t *testing.T) {
+	errs := Errors{}
+	Between("in", errs, "m", "a", "z", "out of range")
+	Between("low", errs, "A", "a", "z", "out of range")
+	Between("high", errs, 2.5, 0.0, 2.0, "out of range")
+	assertErrors(t, errs, "in")
+	assertErrors(t, errs, "low", "out of range")
+	assertErrors(t, errs, "high", "out of range")
+}
+
+func TestIsStringLengthOneSidedMessages(t *testing.T) {
+	errs := Errors{}
+	IsStringLength("max", errs, strings.Repeat("a", 51), 0, 50)
+	IsStringLength("maxOk", errs, strings.Repeat("a", 50), 0, 50)
+	IsStringLength("min", errs, "ab", 3, math.MaxInt)
+	IsStringLength("minOk", errs, "abc", 3, math.MaxInt)
+	IsStringLength("exact", errs, "a", 0, 0)
+
+	assertErrors(t, errs, "max", "Must be at most 50 characters long")
+	assertErrors(t, errs, "maxOk")
+	assertErrors(t, errs, "min", "Must be at least 3 characters long")
+	assertErrors(t, errs, "minOk")
+	assertErrors(t, errs, "exact", "Must be exactly 0 characters long")
+}